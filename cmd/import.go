@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/adapters"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+	"github.com/cesarFuhr/votingAPI/internal/pkg/config"
+	"github.com/cesarFuhr/votingAPI/internal/pkg/logger"
+)
+
+const importCommand = "import-associates"
+
+// runImport imports a csv of associates, writing the report of each row
+// to the stdout as csv. Exits with 1 if any row was rejected or the import
+// stopped, and with 2 on usage errors
+func runImport(args []string) int {
+	fs := flag.NewFlagSet(importCommand, flag.ExitOnError)
+	cfgFromEnv := fs.Bool("e", false, "load config from environment")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %s [-e] <file.csv | ->\n", importCommand)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	var in io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Print(err)
+			return 2
+		}
+		defer f.Close()
+		in = f
+	}
+
+	cfgSource := "yaml"
+	if *cfgFromEnv {
+		cfgSource = "env"
+	}
+	cfg, err := config.LoadConfigs(cfgSource)
+	if err != nil {
+		panic(err)
+	}
+
+	db := bootstrapSQLDatabase(cfg)
+	defer db.Close()
+	l := logger.NewLogger()
+	sqlRepo := adapters.NewSQLRepository(db, l)
+	associateService := associate.NewAssociateService(&sqlRepo)
+
+	out := csv.NewWriter(os.Stdout)
+	out.Write([]string{"row", "id", "document", "status", "reason"})
+	summary, err := associate.ImportCSV(associateService, in, func(row associate.ImportRow) error {
+		status := "rejected"
+		if row.Accepted {
+			status = "accepted"
+		}
+		out.Write([]string{strconv.Itoa(row.Row), row.ID, row.Document, status, row.Reason})
+		return out.Error()
+	})
+	out.Flush()
+
+	fmt.Fprintf(os.Stderr, "%d accepted, %d rejected\n", summary.Accepted, summary.Rejected)
+	if err != nil {
+		log.Printf("import stopped after the last reported row: %v", err)
+		return 1
+	}
+	if summary.Rejected > 0 {
+		return 1
+	}
+	return 0
+}
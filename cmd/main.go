@@ -5,11 +5,13 @@ import (
 	"flag"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	server "github.com/cesarFuhr/votingAPI/internal/app"
 	"github.com/cesarFuhr/votingAPI/internal/app/adapters"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
 	"github.com/cesarFuhr/votingAPI/internal/app/ports"
@@ -19,6 +21,9 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == importCommand {
+		os.Exit(runImport(os.Args[2:]))
+	}
 	run()
 }
 
@@ -32,7 +37,18 @@ func run() {
 
 	time.Sleep(10 * time.Second)
 	db := bootstrapSQLDatabase(cfg)
-	httpServer := bootstrapHTTPServer(cfg, db)
+	l := logger.NewLogger()
+	sqlRepo := adapters.NewSQLRepository(db, l)
+
+	scheduler := bootstrapResultScheduler(cfg, &sqlRepo)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	relay := bootstrapOutboxRelay(cfg, &sqlRepo, l)
+	relay.Start()
+	defer relay.Stop()
+
+	httpServer := bootstrapHTTPServer(cfg, &sqlRepo, l)
 
 	if err := http.ListenAndServe(":"+cfg.Server.Port, httpServer); err != nil {
 		log.Fatalf("could not listen on port 5000 %v", err)
@@ -55,23 +71,72 @@ func bootstrapSQLDatabase(cfg config.Config) *sql.DB {
 	return sqlDB
 }
 
-func bootstrapHTTPServer(cfg config.Config, sqlDB *sql.DB) server.HTTPServer {
-	l := logger.NewLogger()
+func sessionLimits(cfg config.Config) session.Limits {
+	return session.Limits{
+		MinDuration:  cfg.App.MinDuration,
+		MaxDuration:  cfg.App.MaxDuration,
+		MaxExtension: cfg.App.MaxExtension,
+		ReopenWindow: cfg.App.ReopenWindow,
+	}
+}
+
+func bootstrapResultScheduler(cfg config.Config, sqlRepo *adapters.SQLRepository) session.Scheduler {
+	sessionService := session.NewSessionService(sqlRepo, sessionLimits(cfg))
+
+	interval := cfg.App.ResultInterval
+	if interval == 0 {
+		interval = 10 * time.Second
+	}
+	return session.NewResultScheduler(sessionService, sqlRepo, interval)
+}
 
-	sqlRepo := adapters.NewSQLRepository(sqlDB, l)
+func bootstrapOutboxRelay(cfg config.Config, sqlRepo *adapters.SQLRepository, l logger.Logger) session.Relay {
 	mqttPub := adapters.NewMQTTPublisher(cfg.Broker.ConnString, l)
 
-	agendaService := agenda.NewAgendaService(&sqlRepo)
+	interval := cfg.App.RelayInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+	maxAttempts := cfg.App.RelayMaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 10
+	}
+	return session.NewOutboxRelay(sqlRepo, &mqttPub, interval, maxAttempts)
+}
+
+func bootstrapHTTPServer(cfg config.Config, sqlRepo *adapters.SQLRepository, l logger.Logger) server.HTTPServer {
+	agendaService := agenda.NewAgendaService(sqlRepo)
 	agendaHandler := ports.NewAgendaHandler(agendaService)
 
-	sessionService := session.NewSessionService(&sqlRepo, &mqttPub)
+	sessionService := session.NewSessionService(sqlRepo, sessionLimits(cfg))
 	sessionHandler := ports.NewSessionHandler(sessionService)
 	resultHandler := ports.NewResultHandler(sessionService)
 
-	voteService := vote.NewVoteService(&sqlRepo, &adapters.DocValidator{})
+	associateService := associate.NewAssociateService(sqlRepo)
+	associateHandler := ports.NewAssociateHandler(associateService)
+	rosterHandler := ports.NewRosterHandler(associateService)
+
+	voteService := vote.NewVoteService(sqlRepo, vote.NewChecksumValidator(bootstrapDocValidator(cfg, associateService, l)), cfg.App.MaxProxies)
 	voteHandler := ports.NewVoteHandler(voteService)
+	delegationHandler := ports.NewDelegationHandler(voteService)
 
-	return server.NewHTTPServer(l, agendaHandler, sessionHandler, voteHandler, resultHandler)
+	return server.NewHTTPServer(l, agendaHandler, sessionHandler, voteHandler, resultHandler, associateHandler, rosterHandler, delegationHandler)
+}
+
+func bootstrapDocValidator(cfg config.Config, associateService associate.Service, l logger.Logger) vote.DocValidator {
+	if cfg.Validator.Source == "registry" {
+		return adapters.NewRegistryValidator(associateService)
+	}
+	return adapters.NewDocValidator(adapters.DocValidatorConfig{
+		BaseURL:          cfg.Validator.BaseURL,
+		Timeout:          cfg.Validator.Timeout,
+		Retries:          cfg.Validator.Retries,
+		CacheTTL:         cfg.Validator.CacheTTL,
+		CacheSize:        cfg.Validator.CacheSize,
+		BreakerThreshold: cfg.Validator.BreakerThreshold,
+		BreakerCoolDown:  cfg.Validator.BreakerCoolDown,
+		FailOpen:         cfg.Validator.FailOpen,
+	}, l)
 }
 
 func getCfgSource() string {
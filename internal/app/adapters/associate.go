@@ -0,0 +1,150 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+)
+
+const associateColumns = `id, document, name, weight, active, creation, updated`
+
+func scanAssociate(row scanner) (associate.Associate, error) {
+	var a associate.Associate
+	err := row.Scan(
+		&a.ID,
+		&a.Document,
+		&a.Name,
+		&a.Weight,
+		&a.Active,
+		&a.Creation,
+		&a.Update,
+	)
+	return a, err
+}
+
+var findAssociateStatement = `
+	SELECT ` + associateColumns + `
+		FROM associates
+		WHERE id = $1`
+
+// FindAssociate finds and returns the requested associate
+func (r *SQLRepository) FindAssociate(id string) (associate.Associate, error) {
+	return r.findAssociate(findAssociateStatement, id)
+}
+
+var findAssociateByDocumentStatement = `
+	SELECT ` + associateColumns + `
+		FROM associates
+		WHERE document = $1`
+
+// FindAssociateByDocument finds the associate owning the document
+func (r *SQLRepository) FindAssociateByDocument(document string) (associate.Associate, error) {
+	return r.findAssociate(findAssociateByDocumentStatement, document)
+}
+
+func (r *SQLRepository) findAssociate(statement, key string) (associate.Associate, error) {
+	row := r.db.QueryRow(statement, key)
+
+	switch a, err := scanAssociate(row); err {
+	case nil:
+		return a, nil
+	case sql.ErrNoRows:
+		r.l.Info(err.Error(), key)
+		return associate.Associate{}, fmt.Errorf("%w: %s", associate.ErrAssociateNotFound, key)
+	default:
+		r.l.Info(err.Error(), key)
+		return associate.Associate{}, err
+	}
+}
+
+var insertAssociateStatement = `
+	INSERT INTO associates (` + associateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+// InsertAssociate Inserts an associate into the repository
+func (r *SQLRepository) InsertAssociate(a associate.Associate) error {
+	_, err := r.db.Exec(
+		insertAssociateStatement,
+		a.ID,
+		a.Document,
+		a.Name,
+		a.Weight,
+		a.Active,
+		a.Creation,
+		a.Update,
+	)
+	if hasCode(err, uniqueViolation) {
+		r.l.Info(err.Error(), a.ID)
+		return wrap(associate.ErrDuplicateAssociate, err)
+	}
+	return err
+}
+
+var updateAssociateStatement = `
+	UPDATE associates
+		SET document = $2, name = $3, weight = $4, active = $5, updated = $6
+		WHERE id = $1`
+
+// UpdateAssociate Updates an existing associate
+func (r *SQLRepository) UpdateAssociate(a associate.Associate) error {
+	res, err := r.db.Exec(
+		updateAssociateStatement,
+		a.ID,
+		a.Document,
+		a.Name,
+		a.Weight,
+		a.Active,
+		a.Update,
+	)
+	if hasCode(err, uniqueViolation) {
+		r.l.Info(err.Error(), a.ID)
+		return wrap(associate.ErrDuplicateAssociate, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", associate.ErrAssociateNotFound, a.ID)
+	}
+	return nil
+}
+
+var upsertAssociateStatement = `
+	INSERT INTO associates (` + associateColumns + `)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, 0), 1), $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE
+			SET document = EXCLUDED.document,
+				name = EXCLUDED.name,
+				weight = COALESCE(NULLIF($4, 0), associates.weight),
+				active = EXCLUDED.active,
+				updated = EXCLUDED.updated
+		RETURNING ` + associateColumns
+
+// UpsertAssociate Inserts an associate or replaces the one with the same
+// ID, keeping its creation. A zero weight was not informed, inserted
+// associates get the default weight and replaced ones keep theirs
+func (r *SQLRepository) UpsertAssociate(a associate.Associate) (associate.Associate, error) {
+	row := r.db.QueryRow(
+		upsertAssociateStatement,
+		a.ID,
+		a.Document,
+		a.Name,
+		a.Weight,
+		a.Active,
+		a.Creation,
+		a.Update,
+	)
+
+	stored, err := scanAssociate(row)
+	if hasCode(err, uniqueViolation) {
+		r.l.Info(err.Error(), a.ID)
+		return associate.Associate{}, wrap(associate.ErrDuplicateAssociate, err)
+	}
+	return stored, err
+}
@@ -0,0 +1,191 @@
+package adapters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+	"github.com/lib/pq"
+)
+
+var associateMock = associate.Associate{
+	ID:       "string",
+	Document: "52998224725",
+	Name:     "string",
+	Weight:   3,
+	Active:   true,
+	Creation: time.Now(),
+	Update:   time.Now(),
+}
+
+func associateRows(associates ...associate.Associate) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "document", "name", "weight", "active", "creation", "updated"})
+	for _, a := range associates {
+		rows.AddRow(a.ID, a.Document, a.Name, a.Weight, a.Active, a.Creation, a.Update)
+	}
+	return rows
+}
+
+func TestFindAssociate(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns a complete Associate object", func(t *testing.T) {
+		mock.ExpectQuery("SELECT id, document, name, weight, active, creation, updated FROM associates WHERE id").
+			WithArgs(associateMock.ID).
+			WillReturnRows(associateRows(associateMock))
+
+		returned, err := repo.FindAssociate(associateMock.ID)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(associateMock, returned) {
+			t.Errorf("want %v, got %v", associateMock, returned)
+		}
+	})
+
+	t.Run("finds the associate by document", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM associates WHERE document").
+			WithArgs(associateMock.Document).
+			WillReturnRows(associateRows(associateMock))
+
+		returned, err := repo.FindAssociateByDocument(associateMock.Document)
+
+		assertValue(t, err, nil)
+		assertValue(t, returned.ID, associateMock.ID)
+	})
+
+	t.Run("not founding the key, return an Associate not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM associates WHERE id").
+			WithArgs(associateMock.ID).
+			WillReturnRows(associateRows())
+
+		_, got := repo.FindAssociate(associateMock.ID)
+
+		assertValue(t, errors.Is(got, associate.ErrAssociateNotFound), true)
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT (.+) FROM associates WHERE id").WillReturnError(want)
+
+		_, got := repo.FindAssociate(associateMock.ID)
+
+		assertValue(t, got, want)
+	})
+}
+
+func TestInsertAssociate(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("calls db.Exec with the right params", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO associates").
+			WithArgs(
+				associateMock.ID,
+				associateMock.Document,
+				associateMock.Name,
+				associateMock.Weight,
+				associateMock.Active,
+				associateMock.Creation,
+				associateMock.Update,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.InsertAssociate(associateMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a duplicate associate error on unique violations", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO associates").WillReturnError(&pq.Error{Code: "23505"})
+
+		got := repo.InsertAssociate(associateMock)
+
+		assertValue(t, errors.Is(got, associate.ErrDuplicateAssociate), true)
+	})
+}
+
+func TestUpdateAssociate(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("calls db.Exec with the right params", func(t *testing.T) {
+		mock.ExpectExec("UPDATE associates SET document = \\$2, name = \\$3, weight = \\$4, active = \\$5, updated = \\$6 WHERE id = \\$1").
+			WithArgs(
+				associateMock.ID,
+				associateMock.Document,
+				associateMock.Name,
+				associateMock.Weight,
+				associateMock.Active,
+				associateMock.Update,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateAssociate(associateMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns an associate not found if nothing was updated", func(t *testing.T) {
+		mock.ExpectExec("UPDATE associates").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		got := repo.UpdateAssociate(associateMock)
+
+		assertValue(t, errors.Is(got, associate.ErrAssociateNotFound), true)
+	})
+
+	t.Run("returns a duplicate associate error on unique violations", func(t *testing.T) {
+		mock.ExpectExec("UPDATE associates").WillReturnError(&pq.Error{Code: "23505"})
+
+		got := repo.UpdateAssociate(associateMock)
+
+		assertValue(t, errors.Is(got, associate.ErrDuplicateAssociate), true)
+	})
+}
+
+func TestUpsertAssociate(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the stored associate", func(t *testing.T) {
+		stored := associateMock
+		stored.Creation = time.Now().Add(-time.Hour)
+		mock.ExpectQuery("INSERT INTO associates (.+) VALUES \\(\\$1, \\$2, \\$3, COALESCE\\(NULLIF\\(\\$4, 0\\), 1\\)(.+) ON CONFLICT \\(id\\) DO UPDATE (.+) weight = COALESCE\\(NULLIF\\(\\$4, 0\\), associates.weight\\)").
+			WithArgs(
+				associateMock.ID,
+				associateMock.Document,
+				associateMock.Name,
+				associateMock.Weight,
+				associateMock.Active,
+				associateMock.Creation,
+				associateMock.Update,
+			).
+			WillReturnRows(associateRows(stored))
+
+		got, err := repo.UpsertAssociate(associateMock)
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Creation.Equal(stored.Creation), true)
+	})
+
+	t.Run("returns a duplicate associate error if the document is taken", func(t *testing.T) {
+		mock.ExpectQuery("INSERT INTO associates").WillReturnError(&pq.Error{Code: "23505"})
+
+		_, got := repo.UpsertAssociate(associateMock)
+
+		assertValue(t, errors.Is(got, associate.ErrDuplicateAssociate), true)
+	})
+}
@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"database/sql"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+	"github.com/lib/pq"
+)
+
+// ballotCount Representation of the identical ballots of a session summed.
+// Secret ballots are only stored as counts, with no key and no time of
+// their own, so neither the rows nor their order tie a ballot to its voter
+type ballotCount struct {
+	ballot    session.Ballot
+	votes     int
+	weight    int
+	delegated int
+}
+
+// expand returns one ballot per vote summed in the count. Identical
+// ballots are always counted together, so only their summed weight
+// matters and it is split between them as evenly as possible
+func (c ballotCount) expand() []session.Ballot {
+	ballots := make([]session.Ballot, c.votes)
+	for i := range ballots {
+		b := c.ballot
+		b.Weight = c.weight / c.votes
+		if i < c.weight%c.votes {
+			b.Weight++
+		}
+		b.Delegated = i < c.delegated
+		ballots[i] = b
+	}
+	return ballots
+}
+
+var upsertBallotCountStatement = `
+	INSERT INTO ballot_counts (sessionID, vote, ranking, selections, votes, weight, delegated)
+		VALUES ($1, $2, $3, $4, 1, $5, $6)
+		ON CONFLICT (sessionID, vote, ranking, selections) DO UPDATE
+			SET votes = ballot_counts.votes + EXCLUDED.votes,
+				weight = ballot_counts.weight + EXCLUDED.weight,
+				delegated = ballot_counts.delegated + EXCLUDED.delegated`
+
+// countBallot adds the ballot to the count of the identical ballots of the
+// session
+func countBallot(tx *sql.Tx, sessionID string, b session.Ballot) error {
+	delegated := 0
+	if b.Delegated {
+		delegated = 1
+	}
+	_, err := tx.Exec(
+		upsertBallotCountStatement,
+		sessionID,
+		b.Vote,
+		pq.Array(orEmpty(b.Ranking)),
+		pq.Array(orEmpty(b.Selections)),
+		b.Weight,
+		delegated,
+	)
+	return err
+}
+
+// orEmpty keeps the ballots without a ranking or selections comparable,
+// the counts are unique by them and nulls are never equal
+func orEmpty(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return options
+}
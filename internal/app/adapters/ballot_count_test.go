@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+func TestBallotCount(t *testing.T) {
+	t.Run("expands the counts keeping their sums", func(t *testing.T) {
+		c := ballotCount{ballot: session.Ballot{Vote: "S"}, votes: 3, weight: 7, delegated: 2}
+
+		ballots := c.expand()
+
+		weight, delegated := 0, 0
+		for _, b := range ballots {
+			assertValue(t, b.Vote, "S")
+			weight += b.Weight
+			if b.Delegated {
+				delegated++
+			}
+		}
+		assertValue(t, len(ballots), 3)
+		assertValue(t, weight, 7)
+		assertValue(t, delegated, 2)
+	})
+}
@@ -0,0 +1,167 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+const delegationColumns = `id, grantorID, grantorDocument, proxyDocument, scope, agendaID, sessionID, creation`
+
+func scanDelegation(row scanner) (vote.Delegation, error) {
+	var d vote.Delegation
+	var sessionID sql.NullString
+	err := row.Scan(
+		&d.ID,
+		&d.GrantorID,
+		&d.GrantorDocument,
+		&d.ProxyDocument,
+		&d.Scope,
+		&d.AgendaID,
+		&sessionID,
+		&d.Creation,
+	)
+	d.SessionID = sessionID.String
+	return d, err
+}
+
+var lockAgendaDelegationsStatement = `
+	SELECT id
+		FROM agendas
+		WHERE id = $1
+		FOR UPDATE`
+
+var countOverlappingDelegationsStatement = `
+	SELECT COUNT(*)
+		FROM delegations
+		WHERE grantorID = $1 AND agendaID = $2
+			AND ($3::uuid IS NULL OR sessionID IS NULL OR sessionID = $3)`
+
+var countProxyDelegationsStatement = `
+	SELECT COUNT(*)
+		FROM delegations
+		WHERE proxyDocument = $1 AND agendaID = $2`
+
+var insertDelegationStatement = `
+	INSERT INTO delegations (` + delegationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+// InsertDelegation Inserts a delegation into the repository unless the
+// grantor already delegated a vote it covers or the proxy holds maxProxies
+// delegations of the agenda, zero for no limit. The agenda is locked while
+// checking, so concurrent delegations are checked one after the other
+func (r *SQLRepository) InsertDelegation(d vote.Delegation, maxProxies int) error {
+	err := r.insertDelegation(d, maxProxies)
+	if err != nil {
+		r.l.Info(err.Error(), d.ID)
+		if hasCode(err, uniqueViolation) {
+			return wrap(vote.ErrDuplicateDelegation, err)
+		}
+	}
+	return err
+}
+
+func (r *SQLRepository) insertDelegation(d vote.Delegation, maxProxies int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var agendaID string
+	switch err := tx.QueryRow(lockAgendaDelegationsStatement, d.AgendaID).Scan(&agendaID); err {
+	case nil:
+	case sql.ErrNoRows:
+		return fmt.Errorf("%w: %s", agenda.ErrAgendaNotFound, d.AgendaID)
+	default:
+		return err
+	}
+
+	var overlapping int
+	err = tx.QueryRow(countOverlappingDelegationsStatement, d.GrantorID, d.AgendaID, nullable(d.SessionID)).Scan(&overlapping)
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return vote.ErrDuplicateDelegation
+	}
+
+	if maxProxies > 0 {
+		var held int
+		if err := tx.QueryRow(countProxyDelegationsStatement, d.ProxyDocument, d.AgendaID).Scan(&held); err != nil {
+			return err
+		}
+		if held >= maxProxies {
+			return vote.ErrTooManyProxies
+		}
+	}
+
+	_, err = tx.Exec(
+		insertDelegationStatement,
+		d.ID,
+		d.GrantorID,
+		d.GrantorDocument,
+		d.ProxyDocument,
+		d.Scope,
+		d.AgendaID,
+		nullable(d.SessionID),
+		d.Creation,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+var findDelegationStatement = `
+	SELECT ` + delegationColumns + `
+		FROM delegations
+		WHERE id = $1`
+
+// FindDelegation finds and returns the requested delegation
+func (r *SQLRepository) FindDelegation(id string) (vote.Delegation, error) {
+	row := r.db.QueryRow(findDelegationStatement, id)
+
+	switch d, err := scanDelegation(row); err {
+	case nil:
+		return d, nil
+	case sql.ErrNoRows:
+		r.l.Info(err.Error(), id)
+		return vote.Delegation{}, fmt.Errorf("%w: %s", vote.ErrDelegationNotFound, id)
+	default:
+		r.l.Info(err.Error(), id)
+		if hasCode(err, invalidTextRepresentation) {
+			return vote.Delegation{}, wrap(vote.ErrDelegationNotFound, err)
+		}
+		return vote.Delegation{}, err
+	}
+}
+
+var findGrantorDelegationsStatement = `
+	SELECT ` + delegationColumns + `
+		FROM delegations
+		WHERE grantorID = $1 AND agendaID = $2
+		ORDER BY creation`
+
+// FindGrantorDelegations finds the delegations of a grantor in an agenda
+func (r *SQLRepository) FindGrantorDelegations(grantorID, agendaID string) ([]vote.Delegation, error) {
+	rows, err := r.db.Query(findGrantorDelegationsStatement, grantorID, agendaID)
+	if err != nil {
+		r.l.Info(err.Error(), grantorID)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var delegations []vote.Delegation
+	for rows.Next() {
+		d, err := scanDelegation(rows)
+		if err != nil {
+			r.l.Info(err.Error(), grantorID)
+			return nil, err
+		}
+		delegations = append(delegations, d)
+	}
+	return delegations, rows.Err()
+}
@@ -0,0 +1,172 @@
+package adapters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+	"github.com/lib/pq"
+)
+
+var delegationMock = vote.Delegation{
+	ID:              "string",
+	GrantorID:       "string",
+	GrantorDocument: "39053344705",
+	ProxyDocument:   "52998224725",
+	Scope:           vote.ScopeAgenda,
+	AgendaID:        "string",
+	Creation:        time.Now(),
+}
+
+func delegationRows(delegations ...vote.Delegation) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "grantorID", "grantorDocument", "proxyDocument", "scope", "agendaID", "sessionID", "creation",
+	})
+	for _, d := range delegations {
+		var sessionID interface{}
+		if d.SessionID != "" {
+			sessionID = d.SessionID
+		}
+		rows.AddRow(d.ID, d.GrantorID, d.GrantorDocument, d.ProxyDocument, d.Scope, d.AgendaID, sessionID, d.Creation)
+	}
+	return rows
+}
+
+func TestInsertDelegation(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	expectChecks := func(overlapping, held int) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM agendas WHERE id = \\$1 FOR UPDATE").
+			WithArgs(delegationMock.AgendaID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(delegationMock.AgendaID))
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM delegations WHERE grantorID = \\$1 AND agendaID = \\$2").
+			WithArgs(delegationMock.GrantorID, delegationMock.AgendaID, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(overlapping))
+		if overlapping == 0 {
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM delegations WHERE proxyDocument = \\$1 AND agendaID = \\$2").
+				WithArgs(delegationMock.ProxyDocument, delegationMock.AgendaID).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(held))
+		}
+	}
+
+	t.Run("checks and inserts in a transaction locking the agenda", func(t *testing.T) {
+		expectChecks(0, 1)
+		mock.ExpectExec("INSERT INTO delegations").
+			WithArgs(
+				delegationMock.ID,
+				delegationMock.GrantorID,
+				delegationMock.GrantorDocument,
+				delegationMock.ProxyDocument,
+				delegationMock.Scope,
+				delegationMock.AgendaID,
+				nil,
+				delegationMock.Creation,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.InsertDelegation(delegationMock, 2)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("refuses a vote the grantor already delegated", func(t *testing.T) {
+		expectChecks(1, 0)
+		mock.ExpectRollback()
+
+		err := repo.InsertDelegation(delegationMock, 2)
+
+		assertValue(t, err, vote.ErrDuplicateDelegation)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("refuses a proxy holding the maximum of delegations", func(t *testing.T) {
+		expectChecks(0, 2)
+		mock.ExpectRollback()
+
+		err := repo.InsertDelegation(delegationMock, 2)
+
+		assertValue(t, err, vote.ErrTooManyProxies)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a duplicate delegation error on unique violations", func(t *testing.T) {
+		expectChecks(0, 0)
+		mock.ExpectExec("INSERT INTO delegations").
+			WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+
+		err := repo.InsertDelegation(delegationMock, 2)
+
+		assertValue(t, errors.Is(err, vote.ErrDuplicateDelegation), true)
+	})
+
+	t.Run("returns an agenda not found error for unknown agendas", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM agendas").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		err := repo.InsertDelegation(delegationMock, 0)
+
+		assertValue(t, errors.Is(err, agenda.ErrAgendaNotFound), true)
+	})
+}
+
+func TestFindDelegation(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns a complete Delegation object", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM delegations WHERE id").
+			WithArgs(delegationMock.ID).
+			WillReturnRows(delegationRows(delegationMock))
+
+		got, err := repo.FindDelegation(delegationMock.ID)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got, delegationMock) {
+			t.Errorf("want %v, got %v", delegationMock, got)
+		}
+	})
+
+	t.Run("not founding the key, return a Delegation not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM delegations WHERE id").
+			WithArgs(delegationMock.ID).
+			WillReturnRows(delegationRows())
+
+		_, got := repo.FindDelegation(delegationMock.ID)
+
+		assertValue(t, errors.Is(got, vote.ErrDelegationNotFound), true)
+	})
+
+	t.Run("returns the delegations of the grantor in the agenda", func(t *testing.T) {
+		session := delegationMock
+		session.Scope, session.SessionID = vote.ScopeSession, "aSession"
+		mock.ExpectQuery("SELECT (.+) FROM delegations WHERE grantorID = \\$1 AND agendaID = \\$2").
+			WithArgs("aGrantor", "anAgenda").
+			WillReturnRows(delegationRows(delegationMock, session))
+
+		got, err := repo.FindGrantorDelegations("aGrantor", "anAgenda")
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got, []vote.Delegation{delegationMock, session}) {
+			t.Errorf("want %v, got %v", []vote.Delegation{delegationMock, session}, got)
+		}
+	})
+}
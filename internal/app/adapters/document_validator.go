@@ -1,31 +1,202 @@
 package adapters
 
 import (
+	"container/list"
 	"encoding/json"
-	"io/ioutil"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+	"github.com/cesarFuhr/votingAPI/internal/pkg/logger"
 )
 
-const validateServiceURL = "https://user-info.herokuapp.com/users/"
 const ableString = "ABLE_TO_VOTE"
 
+// DocValidatorConfig set of configurations of the remote document validator,
+// zero values other than Retries are replaced by the defaults
+type DocValidatorConfig struct {
+	BaseURL          string
+	Timeout          time.Duration
+	Retries          int
+	CacheTTL         time.Duration
+	CacheSize        int
+	BreakerThreshold int
+	BreakerCoolDown  time.Duration
+	// FailOpen lets everyone vote while the service is unavailable,
+	// otherwise votes are refused with vote.ErrValidatorUnavailable
+	FailOpen bool
+}
+
+var defaultDocValidatorConfig = DocValidatorConfig{
+	BaseURL:          "https://user-info.herokuapp.com/users/",
+	Timeout:          2 * time.Second,
+	CacheTTL:         10 * time.Minute,
+	CacheSize:        10000,
+	BreakerThreshold: 5,
+	BreakerCoolDown:  30 * time.Second,
+}
+
 // DocValidator abstraction to encapsulate the document validation
-type DocValidator struct{}
+type DocValidator struct {
+	cfg     DocValidatorConfig
+	client  *http.Client
+	breaker *circuitBreaker
+	l       logger.Logger
+	now     func() time.Time
+
+	// cache holds the answers in the order they expire, which is the
+	// order they were stored as they share the same TTL
+	mu    sync.Mutex
+	cache map[string]*list.Element
+	order *list.List
+}
+
+type eligibility struct {
+	document string
+	able     bool
+	expires  time.Time
+}
+
+// NewDocValidator creates a validator calling the remote user info service
+func NewDocValidator(cfg DocValidatorConfig, l logger.Logger) *DocValidator {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultDocValidatorConfig.BaseURL
+	}
+	if !strings.HasSuffix(cfg.BaseURL, "/") {
+		cfg.BaseURL += "/"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultDocValidatorConfig.Timeout
+	}
+	if cfg.CacheTTL == 0 {
+		cfg.CacheTTL = defaultDocValidatorConfig.CacheTTL
+	}
+	if cfg.CacheSize == 0 {
+		cfg.CacheSize = defaultDocValidatorConfig.CacheSize
+	}
+	if cfg.BreakerThreshold == 0 {
+		cfg.BreakerThreshold = defaultDocValidatorConfig.BreakerThreshold
+	}
+	if cfg.BreakerCoolDown == 0 {
+		cfg.BreakerCoolDown = defaultDocValidatorConfig.BreakerCoolDown
+	}
+
+	return &DocValidator{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		breaker: newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCoolDown),
+		l:       l,
+		now:     time.Now,
+		cache:   map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
 
 // ValidateDocument returns if document is able to vote
-func (v DocValidator) ValidateDocument(document string) (bool, error) {
-	res, err := http.Get(validateServiceURL + document)
-	if err != nil {
-		return false, err
+func (v *DocValidator) ValidateDocument(document string) (bool, error) {
+	if able, ok := v.cached(document); ok {
+		return able, nil
 	}
 
-	resBytes, err := ioutil.ReadAll(res.Body)
+	if !v.breaker.allow() {
+		return v.unavailable(document, errBreakerOpen)
+	}
+
+	var err error
+	for attempt := 0; attempt <= v.cfg.Retries; attempt++ {
+		var able bool
+		able, err = v.fetch(document)
+		if err == nil {
+			v.breaker.success()
+			v.store(document, able)
+			return able, nil
+		}
+		v.l.Info(err.Error(), document)
+	}
+
+	v.breaker.failure()
+	return v.unavailable(document, err)
+}
+
+var errBreakerOpen = errors.New("circuit breaker open")
+
+func (v *DocValidator) unavailable(document string, cause error) (bool, error) {
+	if v.cfg.FailOpen {
+		v.l.Info("validator unavailable, failing open: ", cause.Error(), document)
+		return true, nil
+	}
+	return false, wrap(vote.ErrValidatorUnavailable, cause)
+}
+
+func (v *DocValidator) fetch(document string) (bool, error) {
+	res, err := v.client.Get(v.cfg.BaseURL + url.PathEscape(document))
 	if err != nil {
 		return false, err
 	}
+	defer res.Body.Close()
+
+	// The service answers unknown or invalid documents with a not found
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, fmt.Errorf("decoding validator response: %w", err)
+	}
+	return body.Status == ableString, nil
+}
+
+func (v *DocValidator) cached(document string) (bool, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	el, ok := v.cache[document]
+	if !ok {
+		return false, false
+	}
+	e := el.Value.(*eligibility)
+	if v.now().After(e.expires) {
+		v.evict(el)
+		return false, false
+	}
+	return e.able, true
+}
+
+// store caches the answer and sweeps the expired answers, evicting the
+// oldest ones while the cache is over its size
+func (v *DocValidator) store(document string, able bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	e := &eligibility{document: document, able: able, expires: now.Add(v.cfg.CacheTTL)}
+	if el, ok := v.cache[document]; ok {
+		el.Value = e
+		v.order.MoveToBack(el)
+	} else {
+		v.cache[document] = v.order.PushBack(e)
+	}
 
-	j := map[string]interface{}{}
-	json.Unmarshal(resBytes, &j)
+	for el := v.order.Front(); el != nil; el = v.order.Front() {
+		if v.order.Len() <= v.cfg.CacheSize && !now.After(el.Value.(*eligibility).expires) {
+			break
+		}
+		v.evict(el)
+	}
+}
 
-	return j["status"] == ableString, nil
+func (v *DocValidator) evict(el *list.Element) {
+	v.order.Remove(el)
+	delete(v.cache, el.Value.(*eligibility).document)
 }
@@ -0,0 +1,182 @@
+package adapters
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+type userInfoStub struct {
+	calls int
+	fail  bool
+}
+
+func (s *userInfoStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.calls++
+	document := strings.TrimPrefix(r.URL.Path, "/users/")
+	switch {
+	case s.fail:
+		w.WriteHeader(http.StatusBadGateway)
+	case document == "unknown":
+		w.WriteHeader(http.StatusNotFound)
+	case document == "garbage":
+		w.Write([]byte(`{"status":`))
+	case document == "able":
+		w.Write([]byte(`{"status":"ABLE_TO_VOTE"}`))
+	default:
+		w.Write([]byte(`{"status":"UNABLE_TO_VOTE"}`))
+	}
+}
+
+func newTestValidator(cfg DocValidatorConfig) (*DocValidator, *userInfoStub, func()) {
+	stub := &userInfoStub{}
+	srv := httptest.NewServer(stub)
+	cfg.BaseURL = srv.URL + "/users"
+	return NewDocValidator(cfg, &loggerStub{}), stub, srv.Close
+}
+
+func TestValidateDocument(t *testing.T) {
+	t.Run("returns the answer of the service", func(t *testing.T) {
+		v, _, stop := newTestValidator(DocValidatorConfig{})
+		defer stop()
+
+		able, err := v.ValidateDocument("able")
+		assertValue(t, err, nil)
+		assertValue(t, able, true)
+
+		able, err = v.ValidateDocument("unable")
+		assertValue(t, err, nil)
+		assertValue(t, able, false)
+	})
+
+	t.Run("treats unknown documents as not able to vote", func(t *testing.T) {
+		v, _, stop := newTestValidator(DocValidatorConfig{})
+		defer stop()
+
+		able, err := v.ValidateDocument("unknown")
+
+		assertValue(t, err, nil)
+		assertValue(t, able, false)
+	})
+
+	t.Run("caches the answers until they expire", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{CacheTTL: time.Minute})
+		defer stop()
+		now := time.Now()
+		v.now = func() time.Time { return now }
+
+		v.ValidateDocument("able")
+		v.ValidateDocument("able")
+		assertValue(t, stub.calls, 1)
+
+		now = now.Add(2 * time.Minute)
+		v.ValidateDocument("able")
+		assertValue(t, stub.calls, 2)
+	})
+
+	t.Run("evicts the oldest answers beyond the cache size", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{CacheSize: 2})
+		defer stop()
+
+		v.ValidateDocument("first")
+		v.ValidateDocument("second")
+		v.ValidateDocument("third")
+		assertValue(t, len(v.cache), 2)
+
+		v.ValidateDocument("third")
+		v.ValidateDocument("first")
+		assertValue(t, stub.calls, 4)
+	})
+
+	t.Run("sweeps the expired answers when storing", func(t *testing.T) {
+		v, _, stop := newTestValidator(DocValidatorConfig{CacheTTL: time.Minute})
+		defer stop()
+		now := time.Now()
+		v.now = func() time.Time { return now }
+
+		v.ValidateDocument("first")
+		v.ValidateDocument("second")
+		now = now.Add(2 * time.Minute)
+		v.ValidateDocument("third")
+
+		assertValue(t, len(v.cache), 1)
+		assertValue(t, v.order.Len(), 1)
+	})
+
+	t.Run("retries and returns a validator unavailable error", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{Retries: 2})
+		defer stop()
+		stub.fail = true
+
+		_, err := v.ValidateDocument("able")
+
+		assertValue(t, errors.Is(err, vote.ErrValidatorUnavailable), true)
+		assertValue(t, stub.calls, 3)
+	})
+
+	t.Run("returns a validator unavailable error on invalid json", func(t *testing.T) {
+		v, _, stop := newTestValidator(DocValidatorConfig{})
+		defer stop()
+
+		_, err := v.ValidateDocument("garbage")
+
+		assertValue(t, errors.Is(err, vote.ErrValidatorUnavailable), true)
+	})
+
+	t.Run("returns a validator unavailable error on timeouts", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+		}))
+		defer srv.Close()
+		v := NewDocValidator(DocValidatorConfig{BaseURL: srv.URL, Timeout: 10 * time.Millisecond}, &loggerStub{})
+
+		_, err := v.ValidateDocument("able")
+
+		assertValue(t, errors.Is(err, vote.ErrValidatorUnavailable), true)
+	})
+
+	t.Run("stops calling the service once the breaker opens", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{BreakerThreshold: 2, BreakerCoolDown: time.Minute})
+		defer stop()
+		stub.fail = true
+
+		v.ValidateDocument("able")
+		v.ValidateDocument("able")
+		_, err := v.ValidateDocument("able")
+
+		assertValue(t, errors.Is(err, vote.ErrValidatorUnavailable), true)
+		assertValue(t, stub.calls, 2)
+	})
+
+	t.Run("probes the service after the cool down", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{BreakerThreshold: 1, BreakerCoolDown: time.Minute})
+		defer stop()
+		now := time.Now()
+		v.breaker.now = func() time.Time { return now }
+		stub.fail = true
+		v.ValidateDocument("able")
+
+		stub.fail = false
+		now = now.Add(2 * time.Minute)
+		able, err := v.ValidateDocument("able")
+
+		assertValue(t, err, nil)
+		assertValue(t, able, true)
+	})
+
+	t.Run("lets the associate vote when failing open", func(t *testing.T) {
+		v, stub, stop := newTestValidator(DocValidatorConfig{FailOpen: true})
+		defer stop()
+		stub.fail = true
+
+		able, err := v.ValidateDocument("unable")
+
+		assertValue(t, err, nil)
+		assertValue(t, able, true)
+	})
+}
@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes the repository translates into domain errors
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	uniqueViolation           = pq.ErrorCode("23505")
+	invalidTextRepresentation = pq.ErrorCode("22P02")
+)
+
+// hasCode reports whether err was raised by postgres with the informed code
+func hasCode(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+// wrap decorates the domain error with the cause reported by the driver,
+// so errors.Is still matches the domain error
+func wrap(domainErr, cause error) error {
+	return fmt.Errorf("%w: %v", domainErr, cause)
+}
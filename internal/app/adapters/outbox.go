@@ -0,0 +1,82 @@
+package adapters
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+var insertEventStatement = `
+	INSERT INTO outbox (id, eventType, payload, status, attempts, lastError, nextAttempt, creation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+func insertEvent(tx *sql.Tx, e session.Event) error {
+	_, err := tx.Exec(
+		insertEventStatement,
+		e.ID,
+		e.Type,
+		e.Payload,
+		e.Status,
+		e.Attempts,
+		e.LastError,
+		e.NextAttempt,
+		e.Creation,
+	)
+	return err
+}
+
+var findPendingEventsStatement = `
+	SELECT id, eventType, payload, status, attempts, lastError, nextAttempt, creation
+		FROM outbox
+		WHERE status = $1 AND nextAttempt <= $2
+		ORDER BY creation
+		LIMIT $3`
+
+// FindPendingEvents Finds the pending events due to be published
+func (r *SQLRepository) FindPendingEvents(t time.Time, limit int) ([]session.Event, error) {
+	rows, err := r.db.Query(findPendingEventsStatement, session.EventPending, t, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []session.Event
+	for rows.Next() {
+		var e session.Event
+		err := rows.Scan(
+			&e.ID,
+			&e.Type,
+			&e.Payload,
+			&e.Status,
+			&e.Attempts,
+			&e.LastError,
+			&e.NextAttempt,
+			&e.Creation,
+		)
+		if err != nil {
+			r.l.Info(err.Error())
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+var updateEventStatement = `
+	UPDATE outbox
+		SET status = $2, attempts = $3, lastError = $4, nextAttempt = $5
+		WHERE id = $1`
+
+// UpdateEvent Updates the delivery state of an event
+func (r *SQLRepository) UpdateEvent(e session.Event) error {
+	_, err := r.db.Exec(
+		updateEventStatement,
+		e.ID,
+		e.Status,
+		e.Attempts,
+		e.LastError,
+		e.NextAttempt,
+	)
+	return err
+}
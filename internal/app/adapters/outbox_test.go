@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+func TestFindPendingEvents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the pending events", func(t *testing.T) {
+		now := time.Now()
+		rows := sqlmock.
+			NewRows([]string{"id", "eventType", "payload", "status", "attempts", "lastError", "nextAttempt", "creation"}).
+			AddRow(eventMock.ID, eventMock.Type, eventMock.Payload, eventMock.Status, 0, "", eventMock.NextAttempt, eventMock.Creation)
+		mock.
+			ExpectQuery("SELECT (.+) FROM outbox WHERE status").
+			WithArgs(session.EventPending, now, 10).
+			WillReturnRows(rows)
+
+		returned, err := repo.FindPendingEvents(now, 10)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual([]session.Event{eventMock}, returned) {
+			t.Errorf("want %v, got %v", eventMock, returned)
+		}
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT (.+) FROM outbox WHERE status").WillReturnError(want)
+
+		_, got := repo.FindPendingEvents(time.Now(), 10)
+
+		assertValue(t, got, want)
+	})
+}
+
+func TestUpdateEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("calls db.Exec with the right params", func(t *testing.T) {
+		mock.ExpectExec("UPDATE outbox").WithArgs(
+			eventMock.ID,
+			eventMock.Status,
+			eventMock.Attempts,
+			eventMock.LastError,
+			anyTime{},
+		).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateEvent(eventMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+}
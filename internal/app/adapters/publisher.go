@@ -2,7 +2,9 @@ package adapters
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 	"github.com/cesarFuhr/votingAPI/internal/pkg/logger"
@@ -10,6 +12,15 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	resultTopic    = "result"
+	atLeastOnce    = 1
+	publishTimeout = 10 * time.Second
+)
+
+// ErrPublishTimeout represents an error caused by a broker not acknowledging a message
+var ErrPublishTimeout = errors.New("Timeout waiting the broker acknowledgement")
+
 // Publisher used to publish messages to the broker
 type Publisher struct {
 	c MQTT.Client
@@ -44,8 +55,16 @@ func (p *Publisher) PublishResult(r session.Result) error {
 		return err
 	}
 
+	token := p.c.Publish(resultTopic, atLeastOnce, false, m)
+	if !token.WaitTimeout(publishTimeout) {
+		p.l.Info("Publish timeout -> ", r.ID)
+		return ErrPublishTimeout
+	}
+	if err := token.Error(); err != nil {
+		p.l.Info("Publish failed -> ", r.ID, " ", err.Error())
+		return err
+	}
+
 	p.l.Info("Published -> ", r.ID, " ", string(m))
-	token := p.c.Publish("result", 0, false, m)
-	token.Wait()
 	return nil
 }
@@ -0,0 +1,19 @@
+package adapters
+
+import "github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+
+// RegistryValidator validates documents against the local associate
+// registry instead of the remote user info service
+type RegistryValidator struct {
+	service associate.Service
+}
+
+// NewRegistryValidator creates a validator backed by the associate registry
+func NewRegistryValidator(s associate.Service) *RegistryValidator {
+	return &RegistryValidator{service: s}
+}
+
+// ValidateDocument returns if document belongs to an active associate
+func (v *RegistryValidator) ValidateDocument(document string) (bool, error) {
+	return v.service.IsEligible(document)
+}
@@ -2,7 +2,7 @@ package adapters
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -10,9 +10,7 @@ import (
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
 	"github.com/cesarFuhr/votingAPI/internal/pkg/logger"
-
-	// Loading the pq driver
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // NewSQLRepository returns a new sql repository instance
@@ -27,7 +25,7 @@ type SQLRepository struct {
 }
 
 var findAgendaStatement = `
-	SELECT id, description
+	SELECT id, description, creation
 		FROM agendas
 		WHERE id = $1`
 
@@ -37,35 +35,201 @@ func (r *SQLRepository) FindAgenda(id string) (agenda.Agenda, error) {
 
 	var a agenda.Agenda
 
-	switch err := row.Scan(&a.ID, &a.Description); err {
+	switch err := row.Scan(&a.ID, &a.Description, &a.Creation); err {
 	case nil:
-		return a, nil
 	case sql.ErrNoRows:
 		r.l.Info(err.Error(), id)
-		return agenda.Agenda{}, errors.New("Agenda not found")
+		return agenda.Agenda{}, fmt.Errorf("%w: %s", agenda.ErrAgendaNotFound, id)
 	default:
+		r.l.Info(err.Error(), id)
+		if hasCode(err, invalidTextRepresentation) {
+			return agenda.Agenda{}, wrap(agenda.ErrAgendaNotFound, err)
+		}
+		return agenda.Agenda{}, err
+	}
+
+	options, err := r.findAgendaOptions(id)
+	if err != nil {
 		r.l.Info(err.Error(), id)
 		return agenda.Agenda{}, err
 	}
+	a.Options = options
+	return a, nil
+}
+
+var findAgendaOptionsStatement = `
+	SELECT id, description
+		FROM agenda_options
+		WHERE agendaID = $1
+		ORDER BY position`
+
+func (r *SQLRepository) findAgendaOptions(agendaID string) ([]agenda.Option, error) {
+	rows, err := r.db.Query(findAgendaOptionsStatement, agendaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var options []agenda.Option
+	for rows.Next() {
+		var o agenda.Option
+		if err := rows.Scan(&o.ID, &o.Description); err != nil {
+			return nil, err
+		}
+		options = append(options, o)
+	}
+	return options, rows.Err()
 }
 
 var insertAgendaStatement = `
 INSERT INTO agendas (id, description, creation)
 VALUES ($1, $2, $3)`
 
-// InsertAgenda Inserts an agenda into the repository
+var insertAgendaOptionStatement = `
+INSERT INTO agenda_options (agendaID, id, description, position)
+VALUES ($1, $2, $3, $4)`
+
+// InsertAgenda Inserts an agenda and its options into the repository
 func (r *SQLRepository) InsertAgenda(a agenda.Agenda) error {
-	_, err := r.db.Exec(
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
 		insertAgendaStatement,
 		a.ID,
 		a.Description,
-		time.Now(),
+		a.Creation,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	for i, o := range a.Options {
+		_, err = tx.Exec(
+			insertAgendaOptionStatement,
+			a.ID,
+			o.ID,
+			o.Description,
+			i,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+var listAgendasStatement = `
+	SELECT id, description, creation, status
+		FROM (
+			SELECT a.id, a.description, a.creation,
+				CASE
+					WHEN NOT EXISTS (
+						SELECT 1 FROM sessions s WHERE s.originalAgenda = a.id
+					) THEN 'new'
+					WHEN EXISTS (
+						SELECT 1 FROM sessions s WHERE s.originalAgenda = a.id
+							AND s.state IN ('scheduled', 'open')
+							AND s.opensAt + (s.duration / 1000) * INTERVAL '1 microsecond' > $1
+					) THEN 'voting'
+					ELSE 'voted'
+				END AS status
+			FROM agendas a
+		) AS listed
+		WHERE true`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListAgendas Lists the agendas matching the query sorted by creation
+func (r *SQLRepository) ListAgendas(q agenda.Query) ([]agenda.Agenda, error) {
+	statement := listAgendasStatement
+	args := []interface{}{q.Now}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if q.Status != "" {
+		statement += " AND status = " + arg(q.Status)
+	}
+	if q.Description != "" {
+		statement += " AND description ILIKE '%' || " + arg(likeEscaper.Replace(q.Description)) + " || '%'"
+	}
+
+	order, comparison := "ASC", ">"
+	if q.Descending {
+		order, comparison = "DESC", "<"
+	}
+	if q.After != nil {
+		statement += fmt.Sprintf(
+			" AND (creation, id) %s (%s, %s::uuid)",
+			comparison, arg(q.After.Creation), arg(q.After.ID),
+		)
+	}
+	statement += fmt.Sprintf(" ORDER BY creation %s, id %s LIMIT %s", order, order, arg(q.Limit))
+
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		r.l.Info(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	agendas := []agenda.Agenda{}
+	for rows.Next() {
+		var a agenda.Agenda
+		if err := rows.Scan(&a.ID, &a.Description, &a.Creation, &a.Status); err != nil {
+			r.l.Info(err.Error())
+			return nil, err
+		}
+		agendas = append(agendas, a)
+	}
+	return agendas, rows.Err()
+}
+
+const sessionColumns = `id, originalAgenda, duration, creation,
+	majority, basis, quorumVoters, quorumPercent, eligibleVoters, rosterID, secret, voteChanges, method, maxSelections, seats, opensAt, state`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row scanner) (session.Session, error) {
+	var s session.Session
+	var rosterID sql.NullString
+	err := row.Scan(
+		&s.ID,
+		&s.OriginalAgenda,
+		&s.Duration,
+		&s.Creation,
+		&s.Rule.Majority,
+		&s.Rule.Basis,
+		&s.Rule.QuorumVoters,
+		&s.Rule.QuorumPercent,
+		&s.Rule.EligibleVoters,
+		&rosterID,
+		&s.Secret,
+		&s.VoteChanges,
+		&s.Method,
+		&s.MaxSelections,
+		&s.Seats,
+		&s.Start,
+		&s.State,
+	)
+	s.RosterID = rosterID.String
+	return s, err
+}
+
+// nullable stores empty strings as NULL, for optional references
+func nullable(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
 
 var findSessionStatement = `
-SELECT id, originalAgenda, duration, creation
+SELECT ` + sessionColumns + `
 FROM sessions
 WHERE id = $1`
 
@@ -73,23 +237,51 @@ WHERE id = $1`
 func (r *SQLRepository) FindSession(id string) (session.Session, error) {
 	row := r.db.QueryRow(findSessionStatement, id)
 
-	var s session.Session
-
-	switch err := row.Scan(&s.ID, &s.OriginalAgenda, &s.Duration, &s.Creation); err {
+	switch s, err := scanSession(row); err {
 	case nil:
 		return s, nil
 	case sql.ErrNoRows:
 		r.l.Info(err.Error(), id)
-		return session.Session{}, errors.New("Session not found")
+		return session.Session{}, fmt.Errorf("%w: %s", session.ErrSessionNotFound, id)
 	default:
 		r.l.Info(err.Error(), id)
+		if hasCode(err, invalidTextRepresentation) {
+			return session.Session{}, wrap(session.ErrSessionNotFound, err)
+		}
 		return session.Session{}, err
 	}
 }
 
+var findSessionsStatement = `
+SELECT ` + sessionColumns + `
+FROM sessions
+WHERE originalAgenda = $1
+ORDER BY creation, id`
+
+// FindSessions finds the sessions opened for an agenda
+func (r *SQLRepository) FindSessions(agendaID string) ([]session.Session, error) {
+	rows, err := r.db.Query(findSessionsStatement, agendaID)
+	if err != nil {
+		r.l.Info(err.Error(), agendaID)
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []session.Session{}
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			r.l.Info(err.Error(), agendaID)
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
 var insertSessionStatement = `
-	INSERT INTO sessions (id, originalAgenda, duration, creation)
-		VALUES ($1, $2, $3, $4)`
+	INSERT INTO sessions (` + sessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
 
 // InsertSession Inserts an session into the repository
 func (r *SQLRepository) InsertSession(s session.Session) error {
@@ -99,13 +291,90 @@ func (r *SQLRepository) InsertSession(s session.Session) error {
 		s.OriginalAgenda,
 		s.Duration,
 		s.Creation,
+		s.Rule.Majority,
+		s.Rule.Basis,
+		s.Rule.QuorumVoters,
+		s.Rule.QuorumPercent,
+		s.Rule.EligibleVoters,
+		nullable(s.RosterID),
+		s.Secret,
+		s.VoteChanges,
+		s.Method,
+		s.MaxSelections,
+		s.Seats,
+		s.Start,
+		s.State,
 	)
 	return err
 }
 
+var findUnpublishedSessionsStatement = `
+SELECT ` + sessionColumns + `
+FROM sessions
+WHERE resultPublished = false
+	AND (state IN ('closed', 'cancelled')
+		OR state IN ('scheduled', 'open') AND opensAt + (duration / 1000) * INTERVAL '1 microsecond' < $1)`
+
+// FindUnpublishedSessions Finds all sessions closed, or expired before the
+// informed time, and cancelled sessions whose results were not published yet
+func (r *SQLRepository) FindUnpublishedSessions(t time.Time) ([]session.Session, error) {
+	rows, err := r.db.Query(findUnpublishedSessionsStatement, t)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []session.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			r.l.Info(err.Error())
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+var markResultPublishedStatement = `
+	UPDATE sessions
+		SET resultPublished = true, resultPublishedAt = $2, state = $3
+		WHERE id = $1 AND resultPublished = false AND state = $4 AND duration = $5`
+
+// MarkResultPublished Marks a session result as published, storing the
+// session state, and writes the result event to the outbox in the same
+// transaction. It returns ErrBadTransition if it was already marked or
+// the session is no longer stored as it was found
+func (r *SQLRepository) MarkResultPublished(found, s session.Session, e session.Event) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(markResultPublishedStatement, s.ID, e.Creation, s.State, found.State, found.Duration)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", session.ErrBadTransition, s.ID)
+	}
+
+	err = insertEvent(tx, e)
+	if err != nil {
+		r.l.Info(err.Error(), s.ID)
+		return err
+	}
+	return tx.Commit()
+}
+
 var insertVoteStatement = `
-	INSERT INTO votes (associateID, sessionID, document, vote, creation)
-		VALUES ($1, $2, $3, $4, $5)`
+	INSERT INTO votes (associateID, sessionID, document, vote, weight, proxyDocument, ranking, selections, creation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
 // InsertVote Inserts a vote into the repository
 func (r *SQLRepository) InsertVote(v vote.Vote) error {
@@ -115,40 +384,123 @@ func (r *SQLRepository) InsertVote(v vote.Vote) error {
 		v.SessionID,
 		v.Document,
 		v.Vote,
+		v.Weight,
+		nullable(v.ProxyDocument),
+		pq.Array(v.Ranking),
+		pq.Array(v.Selections),
 		v.Creation,
 	)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+		if hasCode(err, uniqueViolation) {
 			r.l.Info(err.Error(), v.AssociateID)
-			return vote.ErrDuplicateVote
+			return wrap(vote.ErrDuplicateVote, err)
 		}
 		return err
 	}
 	return nil
 }
 
+var insertParticipationStatement = `
+	INSERT INTO participations (associateID, sessionID, document, proxyDocument)
+		VALUES ($1, $2, $3, $4)`
+
+// InsertSecretVote Inserts who voted, with no creation, and adds what was
+// voted to the counts of the session in the same transaction. The ballot
+// is only kept summed with the identical ones, apart from the participation
+func (r *SQLRepository) InsertSecretVote(v vote.Vote) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		insertParticipationStatement,
+		v.AssociateID,
+		v.SessionID,
+		v.Document,
+		nullable(v.ProxyDocument),
+	)
+	if err != nil {
+		if hasCode(err, uniqueViolation) {
+			r.l.Info(err.Error(), v.AssociateID)
+			return wrap(vote.ErrDuplicateVote, err)
+		}
+		return err
+	}
+
+	err = countBallot(tx, v.SessionID, session.Ballot{
+		Vote:       v.Vote,
+		Weight:     v.Weight,
+		Delegated:  v.ProxyDocument != "",
+		Ranking:    v.Ranking,
+		Selections: v.Selections,
+	})
+	if err != nil {
+		r.l.Info(err.Error(), v.SessionID)
+		return err
+	}
+	return tx.Commit()
+}
+
 var findVotesStatement = `
-	SELECT vote
+	SELECT vote, weight, proxyDocument IS NOT NULL, ranking, selections
 		FROM votes
 		WHERE sessionID = $1`
 
-// FindVotes Finds all votes by sessionID
-func (r *SQLRepository) FindVotes(s session.Session) ([]string, error) {
+// FindVotes Finds all votes by sessionID, the ballots for secret sessions
+func (r *SQLRepository) FindVotes(s session.Session) ([]session.Ballot, error) {
+	if s.Secret {
+		return r.findBallots(s)
+	}
 	rows, err := r.db.Query(findVotesStatement, s.ID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var voteValues []string
+	var ballots []session.Ballot
 	for rows.Next() {
-		var v string
-		err := rows.Scan(&v)
+		var b session.Ballot
+		err := rows.Scan(&b.Vote, &b.Weight, &b.Delegated, pq.Array(&b.Ranking), pq.Array(&b.Selections))
 		if err != nil {
 			r.l.Info(err.Error(), s.ID)
 			return nil, err
 		}
-		voteValues = append(voteValues, v)
+		ballots = append(ballots, b)
+	}
+	return ballots, nil
+}
+
+var findBallotCountsStatement = `
+	SELECT vote, ranking, selections, votes, weight, delegated
+		FROM ballot_counts
+		WHERE sessionID = $1`
+
+// findBallots returns the ballots of the secret session, one per vote
+// summed in its counts
+func (r *SQLRepository) findBallots(s session.Session) ([]session.Ballot, error) {
+	rows, err := r.db.Query(findBallotCountsStatement, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ballots []session.Ballot
+	for rows.Next() {
+		var c ballotCount
+		err := rows.Scan(&c.ballot.Vote, pq.Array(&c.ballot.Ranking), pq.Array(&c.ballot.Selections), &c.votes, &c.weight, &c.delegated)
+		if err != nil {
+			r.l.Info(err.Error(), s.ID)
+			return nil, err
+		}
+		if len(c.ballot.Ranking) == 0 {
+			c.ballot.Ranking = nil
+		}
+		if len(c.ballot.Selections) == 0 {
+			c.ballot.Selections = nil
+		}
+		ballots = append(ballots, c.expand()...)
 	}
-	return voteValues, nil
+	return ballots, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+	"github.com/lib/pq"
 )
 
 type anyTime struct{}
@@ -23,6 +24,8 @@ func (a anyTime) Match(v driver.Value) bool {
 var agendaMock = agenda.Agenda{
 	ID:          "string",
 	Description: "string",
+	Options:     agenda.DefaultOptions,
+	Creation:    time.Now(),
 }
 
 var sessionMock = session.Session{
@@ -30,6 +33,33 @@ var sessionMock = session.Session{
 	OriginalAgenda: "string",
 	Duration:       time.Minute,
 	Creation:       time.Now(),
+	State:          session.StateOpen,
+	Rule: session.DecisionRule{
+		Majority:       session.AbsoluteMajority,
+		Basis:          session.WeightedBasis,
+		QuorumVoters:   10,
+		QuorumPercent:  50,
+		EligibleVoters: 30,
+	},
+}
+
+func sessionRows(sessions ...session.Session) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "originalAgenda", "duration", "creation",
+		"majority", "basis", "quorumVoters", "quorumPercent", "eligibleVoters", "rosterID", "secret", "voteChanges", "method", "maxSelections", "seats", "opensAt", "state",
+	})
+	for _, s := range sessions {
+		var rosterID interface{}
+		if s.RosterID != "" {
+			rosterID = s.RosterID
+		}
+		rows.AddRow(
+			s.ID, s.OriginalAgenda, s.Duration, s.Creation,
+			s.Rule.Majority, s.Rule.Basis, s.Rule.QuorumVoters, s.Rule.QuorumPercent, s.Rule.EligibleVoters,
+			rosterID, s.Secret, s.VoteChanges, s.Method, s.MaxSelections, s.Seats, s.Start, s.State,
+		)
+	}
+	return rows
 }
 
 var voteMock = vote.Vote{
@@ -37,6 +67,16 @@ var voteMock = vote.Vote{
 	SessionID:   "string",
 	Document:    "12333",
 	Vote:        "S",
+	Weight:      2,
+	Creation:    time.Now(),
+}
+
+var eventMock = session.Event{
+	ID:          "string",
+	Type:        session.ResultEvent,
+	Payload:     []byte(`{"ID":"string"}`),
+	Status:      session.EventPending,
+	NextAttempt: time.Now(),
 	Creation:    time.Now(),
 }
 
@@ -50,11 +90,21 @@ func TestInsertAgenda(t *testing.T) {
 	defer db.Close()
 
 	t.Run("calls db.Exec with the right params", func(t *testing.T) {
-		mock.ExpectExec("INSERT INTO agenda").WithArgs(
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO agendas").WithArgs(
 			agendaMock.ID,
 			agendaMock.Description,
 			anyTime{},
-		)
+		).WillReturnResult(sqlmock.NewResult(0, 1))
+		for i, o := range agendaMock.Options {
+			mock.ExpectExec("INSERT INTO agenda_options").WithArgs(
+				agendaMock.ID,
+				o.ID,
+				o.Description,
+				i,
+			).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
 
 		repo.InsertAgenda(agendaMock)
 
@@ -65,11 +115,13 @@ func TestInsertAgenda(t *testing.T) {
 
 	t.Run("proxys the error from the sql db", func(t *testing.T) {
 		want := errors.New("an error")
+		mock.ExpectBegin()
 		mock.ExpectExec("INSERT INTO agendas").WithArgs(
 			agendaMock.ID,
 			agendaMock.Description,
 			anyTime{},
 		).WillReturnError(want)
+		mock.ExpectRollback()
 
 		got := repo.InsertAgenda(agendaMock)
 
@@ -84,7 +136,7 @@ func TestFindAgenda(t *testing.T) {
 
 	t.Run("calls db.QueryRow with the right params", func(t *testing.T) {
 		mock.ExpectQuery(`
-			SELECT id, description
+			SELECT id, description, creation
 				FROM agendas
 				WHERE id`).WithArgs(agendaMock.ID)
 
@@ -97,15 +149,26 @@ func TestFindAgenda(t *testing.T) {
 
 	t.Run("returns a complete Agenda object", func(t *testing.T) {
 		rows := sqlmock.
-			NewRows([]string{"id", "description"}).
-			AddRow(agendaMock.ID, agendaMock.Description)
+			NewRows([]string{"id", "description", "creation"}).
+			AddRow(agendaMock.ID, agendaMock.Description, agendaMock.Creation)
 		mock.
 			ExpectQuery(`
-					SELECT id, description
+					SELECT id, description, creation
 						FROM agendas
 						WHERE id`).
 			WithArgs(agendaMock.ID).
 			WillReturnRows(rows)
+		optionRows := sqlmock.NewRows([]string{"id", "description"})
+		for _, o := range agendaMock.Options {
+			optionRows.AddRow(o.ID, o.Description)
+		}
+		mock.
+			ExpectQuery(`
+					SELECT id, description
+						FROM agenda_options
+						WHERE agendaID`).
+			WithArgs(agendaMock.ID).
+			WillReturnRows(optionRows)
 
 		returned, err := repo.FindAgenda(agendaMock.ID)
 
@@ -118,7 +181,7 @@ func TestFindAgenda(t *testing.T) {
 	t.Run("proxys the error from the sql db", func(t *testing.T) {
 		want := errors.New("an error")
 		mock.ExpectQuery(`
-				SELECT id, description
+				SELECT id, description, creation
 					FROM agendas
 					WHERE id`).WithArgs(agendaMock.ID).WillReturnError(want)
 
@@ -128,15 +191,77 @@ func TestFindAgenda(t *testing.T) {
 	})
 
 	t.Run("not founding the key, return a Agenda not Found", func(t *testing.T) {
-		want := errors.New("Agenda not found")
 		mock.ExpectQuery(`
-				SELECT id, description
+				SELECT id, description, creation
 					FROM agendas
 					WHERE id`).WithArgs(agendaMock.ID).WillReturnRows(sqlmock.NewRows([]string{}))
 
 		_, got := repo.FindAgenda(agendaMock.ID)
 
-		assertValue(t, got.Error(), want.Error())
+		assertValue(t, errors.Is(got, agenda.ErrAgendaNotFound), true)
+	})
+
+	t.Run("not being a valid id, return a Agenda not Found", func(t *testing.T) {
+		mock.ExpectQuery(`
+				SELECT id, description, creation
+					FROM agendas
+					WHERE id`).WithArgs("notAnID").WillReturnError(&pq.Error{Code: "22P02"})
+
+		_, got := repo.FindAgenda("notAnID")
+
+		assertValue(t, errors.Is(got, agenda.ErrAgendaNotFound), true)
+	})
+}
+
+func TestListAgendas(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("filters, paginates and sorts with the right params", func(t *testing.T) {
+		now := time.Now()
+		after := agenda.Cursor{Creation: now.Add(-time.Hour), ID: "anID"}
+		rows := sqlmock.
+			NewRows([]string{"id", "description", "creation", "status"}).
+			AddRow(agendaMock.ID, agendaMock.Description, agendaMock.Creation, agenda.StatusVoting)
+		mock.
+			ExpectQuery(`SELECT id, description, creation, status FROM (.+) WHERE true `+
+				`AND status = \$2 AND description ILIKE (.+) AND \(creation, id\) < (.+) `+
+				`ORDER BY creation DESC, id DESC LIMIT \$6`).
+			WithArgs(now, agenda.StatusVoting, `50\%`, after.Creation, after.ID, 10).
+			WillReturnRows(rows)
+
+		returned, err := repo.ListAgendas(agenda.Query{
+			Now:         now,
+			Status:      agenda.StatusVoting,
+			Description: "50%",
+			Descending:  true,
+			After:       &after,
+			Limit:       10,
+		})
+
+		assertValue(t, err, nil)
+		want := []agenda.Agenda{{
+			ID:          agendaMock.ID,
+			Description: agendaMock.Description,
+			Creation:    agendaMock.Creation,
+			Status:      agenda.StatusVoting,
+		}}
+		if !reflect.DeepEqual(want, returned) {
+			t.Errorf("want %v, got %v", want, returned)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT id, description, creation, status FROM").WillReturnError(want)
+
+		_, got := repo.ListAgendas(agenda.Query{Limit: 10})
+
+		assertValue(t, got, want)
 	})
 }
 
@@ -151,6 +276,19 @@ func TestInsertSession(t *testing.T) {
 			sessionMock.OriginalAgenda,
 			sessionMock.Duration,
 			anyTime{},
+			sessionMock.Rule.Majority,
+			sessionMock.Rule.Basis,
+			sessionMock.Rule.QuorumVoters,
+			sessionMock.Rule.QuorumPercent,
+			sessionMock.Rule.EligibleVoters,
+			nil,
+			sessionMock.Secret,
+			sessionMock.VoteChanges,
+			sessionMock.Method,
+			sessionMock.MaxSelections,
+			sessionMock.Seats,
+			anyTime{},
+			sessionMock.State,
 		)
 
 		repo.InsertSession(sessionMock)
@@ -167,6 +305,19 @@ func TestInsertSession(t *testing.T) {
 			sessionMock.OriginalAgenda,
 			sessionMock.Duration,
 			anyTime{},
+			sessionMock.Rule.Majority,
+			sessionMock.Rule.Basis,
+			sessionMock.Rule.QuorumVoters,
+			sessionMock.Rule.QuorumPercent,
+			sessionMock.Rule.EligibleVoters,
+			nil,
+			sessionMock.Secret,
+			sessionMock.VoteChanges,
+			sessionMock.Method,
+			sessionMock.MaxSelections,
+			sessionMock.Seats,
+			anyTime{},
+			sessionMock.State,
 		).WillReturnError(want)
 
 		got := repo.InsertSession(sessionMock)
@@ -182,7 +333,7 @@ func TestFindSession(t *testing.T) {
 
 	t.Run("calls db.QueryRow with the right params", func(t *testing.T) {
 		mock.ExpectQuery(`
-		SELECT id, originalAgenda, duration, creation
+		SELECT id, originalAgenda, duration, creation, (.+)
 				FROM sessions
 				WHERE id`).WithArgs(sessionMock.ID)
 
@@ -194,12 +345,10 @@ func TestFindSession(t *testing.T) {
 	})
 
 	t.Run("returns a complete Session object", func(t *testing.T) {
-		rows := sqlmock.
-			NewRows([]string{"id", "originalAgenda", "duration", "creation"}).
-			AddRow(sessionMock.ID, sessionMock.OriginalAgenda, sessionMock.Duration, sessionMock.Creation)
+		rows := sessionRows(sessionMock)
 		mock.
 			ExpectQuery(`
-					SELECT id, originalAgenda, duration, creation
+					SELECT id, originalAgenda, duration, creation, (.+)
 					FROM sessions
 					WHERE id`).
 			WithArgs(sessionMock.ID).
@@ -213,10 +362,23 @@ func TestFindSession(t *testing.T) {
 		}
 	})
 
+	t.Run("returns the roster of restricted sessions", func(t *testing.T) {
+		restricted := sessionMock
+		restricted.RosterID = "aRoster"
+		mock.ExpectQuery("SELECT (.+) FROM sessions WHERE id").
+			WithArgs(restricted.ID).
+			WillReturnRows(sessionRows(restricted))
+
+		returned, err := repo.FindSession(restricted.ID)
+
+		assertValue(t, err, nil)
+		assertValue(t, returned.RosterID, "aRoster")
+	})
+
 	t.Run("proxys the error from the sql db", func(t *testing.T) {
 		want := errors.New("an error")
 		mock.ExpectQuery(`
-		SELECT id, originalAgenda, duration, creation
+		SELECT id, originalAgenda, duration, creation, (.+)
 		FROM sessions
 		WHERE id`).WithArgs(sessionMock.ID).WillReturnError(want)
 
@@ -226,15 +388,159 @@ func TestFindSession(t *testing.T) {
 	})
 
 	t.Run("not founding the key, return a Session not found", func(t *testing.T) {
-		want := errors.New("Session not found")
 		mock.ExpectQuery(`
-				SELECT id, originalAgenda, duration, creation
+				SELECT id, originalAgenda, duration, creation, (.+)
 				FROM sessions
 					WHERE id`).WithArgs(sessionMock.ID).WillReturnRows(sqlmock.NewRows([]string{}))
 
 		_, got := repo.FindSession(sessionMock.ID)
 
-		assertValue(t, got.Error(), want.Error())
+		assertValue(t, errors.Is(got, session.ErrSessionNotFound), true)
+	})
+
+	t.Run("not being a valid id, return a Session not found", func(t *testing.T) {
+		mock.ExpectQuery(`
+				SELECT id, originalAgenda, duration, creation, (.+)
+				FROM sessions
+					WHERE id`).WithArgs("notAnID").WillReturnError(&pq.Error{Code: "22P02"})
+
+		_, got := repo.FindSession("notAnID")
+
+		assertValue(t, errors.Is(got, session.ErrSessionNotFound), true)
+	})
+}
+
+func TestFindSessions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the sessions of the agenda", func(t *testing.T) {
+		rows := sessionRows(sessionMock)
+		mock.
+			ExpectQuery("SELECT id, originalAgenda, duration, creation, (.+) FROM sessions WHERE originalAgenda = \\$1 ORDER BY creation, id").
+			WithArgs(sessionMock.OriginalAgenda).
+			WillReturnRows(rows)
+
+		returned, err := repo.FindSessions(sessionMock.OriginalAgenda)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual([]session.Session{sessionMock}, returned) {
+			t.Errorf("want %v, got %v", sessionMock, returned)
+		}
+	})
+
+	t.Run("returns an empty list if the agenda has no sessions", func(t *testing.T) {
+		mock.
+			ExpectQuery("SELECT id, originalAgenda, duration, creation, (.+) FROM sessions WHERE originalAgenda").
+			WithArgs(sessionMock.OriginalAgenda).
+			WillReturnRows(sessionRows())
+
+		returned, err := repo.FindSessions(sessionMock.OriginalAgenda)
+
+		assertValue(t, err, nil)
+		assertValue(t, len(returned), 0)
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT id, originalAgenda, duration, creation, (.+) FROM sessions WHERE originalAgenda").
+			WillReturnError(want)
+
+		_, got := repo.FindSessions(sessionMock.OriginalAgenda)
+
+		assertValue(t, got, want)
+	})
+}
+
+func TestFindUnpublishedSessions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the unpublished expired sessions", func(t *testing.T) {
+		now := time.Now()
+		rows := sessionRows(sessionMock)
+		mock.
+			ExpectQuery("SELECT id, originalAgenda, duration, creation, (.+) FROM sessions WHERE resultPublished = false").
+			WithArgs(now).
+			WillReturnRows(rows)
+
+		returned, err := repo.FindUnpublishedSessions(now)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual([]session.Session{sessionMock}, returned) {
+			t.Errorf("want %v, got %v", sessionMock, returned)
+		}
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT id, originalAgenda, duration, creation, (.+) FROM sessions WHERE resultPublished = false").
+			WillReturnError(want)
+
+		_, got := repo.FindUnpublishedSessions(time.Now())
+
+		assertValue(t, got, want)
+	})
+}
+
+func TestMarkResultPublished(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	found := sessionMock
+	found.State = session.StateOpen
+
+	t.Run("marks the session and writes the event in the same transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE sessions SET resultPublished = true(.+) WHERE id = \\$1 AND resultPublished = false AND state = \\$4 AND duration = \\$5").
+			WithArgs(sessionMock.ID, anyTime{}, sessionMock.State, found.State, found.Duration).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO outbox").
+			WithArgs(eventMock.ID, eventMock.Type, eventMock.Payload, eventMock.Status, 0, "", anyTime{}, anyTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.MarkResultPublished(found, sessionMock, eventMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a bad transition without writing the event if it was already published", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE sessions SET resultPublished = true").
+			WithArgs(sessionMock.ID, anyTime{}, sessionMock.State, found.State, found.Duration).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.MarkResultPublished(found, sessionMock, eventMock)
+
+		assertValue(t, errors.Is(err, session.ErrBadTransition), true)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("rolls back if the event could not be written", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE sessions SET resultPublished = true").
+			WithArgs(sessionMock.ID, anyTime{}, sessionMock.State, found.State, found.Duration).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO outbox").WillReturnError(want)
+		mock.ExpectRollback()
+
+		got := repo.MarkResultPublished(found, sessionMock, eventMock)
+
+		assertValue(t, got, want)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
 	})
 }
 
@@ -249,6 +555,10 @@ func TestInsertVote(t *testing.T) {
 			voteMock.SessionID,
 			voteMock.Document,
 			voteMock.Vote,
+			voteMock.Weight,
+			nil,
+			nil,
+			nil,
 			anyTime{},
 		)
 
@@ -266,6 +576,10 @@ func TestInsertVote(t *testing.T) {
 			voteMock.SessionID,
 			voteMock.Document,
 			voteMock.Vote,
+			voteMock.Weight,
+			nil,
+			nil,
+			nil,
 			anyTime{},
 		).WillReturnError(want)
 
@@ -273,6 +587,94 @@ func TestInsertVote(t *testing.T) {
 
 		assertValue(t, got, want)
 	})
+
+	t.Run("returns a duplicate vote error on unique violations", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO votes").WithArgs(
+			voteMock.AssociateID,
+			voteMock.SessionID,
+			voteMock.Document,
+			voteMock.Vote,
+			voteMock.Weight,
+			nil,
+			nil,
+			nil,
+			anyTime{},
+		).WillReturnError(&pq.Error{Code: "23505", Message: "a driver message"})
+
+		got := repo.InsertVote(voteMock)
+
+		assertValue(t, errors.Is(got, vote.ErrDuplicateVote), true)
+	})
+}
+
+func TestInsertSecretVote(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("inserts the participation and counts the ballot in one transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO participations \\(associateID, sessionID, document, proxyDocument\\)").
+			WithArgs(voteMock.AssociateID, voteMock.SessionID, voteMock.Document, nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ballot_counts \\(sessionID, vote, ranking, selections, votes, weight, delegated\\)").
+			WithArgs(voteMock.SessionID, voteMock.Vote, "{}", "{}", voteMock.Weight, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.InsertSecretVote(voteMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("counts the ballot cast by a proxy as delegated", func(t *testing.T) {
+		proxied := voteMock
+		proxied.ProxyDocument = "11222333000181"
+		proxied.Ranking = []string{"S", "N"}
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO participations").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ballot_counts").
+			WithArgs(proxied.SessionID, proxied.Vote, "{\"S\",\"N\"}", "{}", proxied.Weight, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.InsertSecretVote(proxied)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns the error and keeps no participation if the ballot could not be counted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO participations").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO ballot_counts").WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		err := repo.InsertSecretVote(voteMock)
+
+		assertValue(t, err.Error(), "connection reset")
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a duplicate vote error without counting the ballot", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO participations").WillReturnError(&pq.Error{Code: "23505"})
+		mock.ExpectRollback()
+
+		got := repo.InsertSecretVote(voteMock)
+
+		assertValue(t, errors.Is(got, vote.ErrDuplicateVote), true)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
 }
 
 func TestFindVotes(t *testing.T) {
@@ -281,7 +683,7 @@ func TestFindVotes(t *testing.T) {
 	defer db.Close()
 
 	t.Run("calls db.Exec with the right params", func(t *testing.T) {
-		mock.ExpectQuery("SELECT vote FROM votes WHERE sessionID").
+		mock.ExpectQuery("SELECT vote, weight, proxyDocument IS NOT NULL, ranking, selections FROM votes WHERE sessionID").
 			WithArgs(sessionMock.ID)
 
 		repo.FindVotes(sessionMock)
@@ -291,32 +693,88 @@ func TestFindVotes(t *testing.T) {
 		}
 	})
 
-	t.Run("returns a list of vote values", func(t *testing.T) {
+	t.Run("returns a list of vote values and weights", func(t *testing.T) {
 		rows := sqlmock.
-			NewRows([]string{"vote"}).
-			AddRow("S").
-			AddRow("N").
-			AddRow("S").
-			AddRow("S")
+			NewRows([]string{"vote", "weight", "delegated", "ranking", "selections"}).
+			AddRow("S", 1, false, nil, nil).
+			AddRow("N", 4, true, nil, nil).
+			AddRow("S", 2, false, nil, nil).
+			AddRow("S", 1, false, nil, nil)
 		mock.
 			ExpectQuery(`
-					SELECT vote
+					SELECT vote, weight, (.+)
 					FROM votes
 					WHERE sessionID`).
 			WithArgs(sessionMock.ID).
 			WillReturnRows(rows)
 
 		returned, err := repo.FindVotes(sessionMock)
+		want := []session.Ballot{
+			{Vote: "S", Weight: 1},
+			{Vote: "N", Weight: 4, Delegated: true},
+			{Vote: "S", Weight: 2},
+			{Vote: "S", Weight: 1},
+		}
 
 		assertValue(t, err, nil)
-		if !reflect.DeepEqual([]string{"S", "N", "S", "S"}, returned) {
-			t.Errorf("want %v, got %v", sessionMock, returned)
+		if !reflect.DeepEqual(want, returned) {
+			t.Errorf("want %v, got %v", want, returned)
+		}
+	})
+
+	t.Run("counts the ballots of secret sessions", func(t *testing.T) {
+		secret := sessionMock
+		secret.Secret = true
+		mock.ExpectQuery("SELECT vote, ranking, selections, votes, weight, delegated FROM ballot_counts WHERE sessionID").
+			WithArgs(secret.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"vote", "ranking", "selections", "votes", "weight", "delegated"}).
+				AddRow("N", "{}", "{}", 2, 5, 1).
+				AddRow("S", "{S,N}", "{}", 1, 1, 0))
+
+		returned, err := repo.FindVotes(secret)
+		want := []session.Ballot{
+			{Vote: "N", Weight: 3, Delegated: true},
+			{Vote: "N", Weight: 2},
+			{Vote: "S", Weight: 1, Ranking: []string{"S", "N"}},
+		}
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(want, returned) {
+			t.Errorf("want %v, got %v", want, returned)
+		}
+	})
+
+	t.Run("returns the rankings of ranked sessions", func(t *testing.T) {
+		mock.ExpectQuery("SELECT vote, weight, (.+) FROM votes WHERE sessionID").
+			WithArgs(sessionMock.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"vote", "weight", "delegated", "ranking", "selections"}).AddRow("N", 1, false, "{N,S}", nil))
+
+		returned, err := repo.FindVotes(sessionMock)
+		want := []session.Ballot{{Vote: "N", Weight: 1, Ranking: []string{"N", "S"}}}
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(want, returned) {
+			t.Errorf("want %v, got %v", want, returned)
+		}
+	})
+
+	t.Run("returns the selections of approval sessions", func(t *testing.T) {
+		mock.ExpectQuery("SELECT vote, weight, (.+) FROM votes WHERE sessionID").
+			WithArgs(sessionMock.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"vote", "weight", "delegated", "ranking", "selections"}).AddRow("S", 1, false, nil, "{S,N}"))
+
+		returned, err := repo.FindVotes(sessionMock)
+		want := []session.Ballot{{Vote: "S", Weight: 1, Selections: []string{"S", "N"}}}
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(want, returned) {
+			t.Errorf("want %v, got %v", want, returned)
 		}
 	})
 
 	t.Run("proxys the error from the sql db", func(t *testing.T) {
 		want := errors.New("an error")
-		mock.ExpectQuery("SELECT vote FROM votes WHERE sessionID").
+		mock.ExpectQuery("SELECT vote, weight, (.+) FROM votes WHERE sessionID").
 			WithArgs(sessionMock.ID).WillReturnError(want)
 
 		_, got := repo.FindVotes(sessionMock)
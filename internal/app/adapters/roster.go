@@ -0,0 +1,164 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+)
+
+var insertRosterStatement = `
+	INSERT INTO rosters (id, name, size, creation)
+		VALUES ($1, $2, $3, $4)`
+
+var insertRosterMemberStatement = `
+	INSERT INTO roster_members (rosterID, document, associateID, weight)
+		VALUES ($1, $2, $3, $4)`
+
+// InsertRoster Inserts a roster and its members into the repository
+func (r *SQLRepository) InsertRoster(roster associate.Roster) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		insertRosterStatement,
+		roster.ID,
+		roster.Name,
+		roster.Size,
+		roster.Creation,
+	)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(insertRosterMemberStatement)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, m := range roster.Members {
+		if _, err := stmt.Exec(roster.ID, m.Document, nullable(m.AssociateID), m.Weight); err != nil {
+			r.l.Info(err.Error(), roster.ID)
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+var findRosterStatement = `
+	SELECT id, name, size, creation
+		FROM rosters
+		WHERE id = $1`
+
+var findRosterMembersStatement = `
+	SELECT document, associateID, weight
+		FROM roster_members
+		WHERE rosterID = $1
+		ORDER BY document`
+
+// FindRoster finds and returns the requested roster with its members
+func (r *SQLRepository) FindRoster(id string) (associate.Roster, error) {
+	roster, err := r.findRoster(id)
+	if err != nil {
+		return associate.Roster{}, err
+	}
+
+	rows, err := r.db.Query(findRosterMembersStatement, id)
+	if err != nil {
+		r.l.Info(err.Error(), id)
+		return associate.Roster{}, err
+	}
+	defer rows.Close()
+
+	roster.Members = make([]associate.Member, 0, roster.Size)
+	for rows.Next() {
+		var m associate.Member
+		var associateID sql.NullString
+		if err := rows.Scan(&m.Document, &associateID, &m.Weight); err != nil {
+			r.l.Info(err.Error(), id)
+			return associate.Roster{}, err
+		}
+		m.AssociateID = associateID.String
+		roster.Members = append(roster.Members, m)
+	}
+	return roster, rows.Err()
+}
+
+// FindRosterSize returns the number of members of a roster
+func (r *SQLRepository) FindRosterSize(id string) (int, error) {
+	roster, err := r.findRoster(id)
+	return roster.Size, err
+}
+
+func (r *SQLRepository) findRoster(id string) (associate.Roster, error) {
+	row := r.db.QueryRow(findRosterStatement, id)
+
+	var roster associate.Roster
+	switch err := row.Scan(&roster.ID, &roster.Name, &roster.Size, &roster.Creation); err {
+	case nil:
+		return roster, nil
+	case sql.ErrNoRows:
+		r.l.Info(err.Error(), id)
+		return associate.Roster{}, fmt.Errorf("%w: %s", associate.ErrRosterNotFound, id)
+	default:
+		r.l.Info(err.Error(), id)
+		if hasCode(err, invalidTextRepresentation) {
+			return associate.Roster{}, wrap(associate.ErrRosterNotFound, err)
+		}
+		return associate.Roster{}, err
+	}
+}
+
+var isRosterMemberStatement = `
+	SELECT EXISTS (
+		SELECT 1 FROM roster_members
+			WHERE rosterID = $1 AND document = $2
+	)`
+
+// IsRosterMember reports whether the document is listed in the roster
+func (r *SQLRepository) IsRosterMember(rosterID, document string) (bool, error) {
+	var member bool
+	err := r.db.QueryRow(isRosterMemberStatement, rosterID, document).Scan(&member)
+	if err != nil {
+		r.l.Info(err.Error(), rosterID)
+		return false, err
+	}
+	return member, nil
+}
+
+var findRosterMemberWeightStatement = `
+	SELECT weight
+		FROM roster_members
+		WHERE rosterID = $1 AND document = $2`
+
+var findAssociateWeightStatement = `
+	SELECT weight
+		FROM associates
+		WHERE document = $1`
+
+// FindVoterWeight returns the weight of the document inside the roster or,
+// without a roster, in the associate registry. Documents unknown to the
+// registry weigh one
+func (r *SQLRepository) FindVoterWeight(rosterID, document string) (int, error) {
+	var row *sql.Row
+	if rosterID != "" {
+		row = r.db.QueryRow(findRosterMemberWeightStatement, rosterID, document)
+	} else {
+		row = r.db.QueryRow(findAssociateWeightStatement, document)
+	}
+
+	var weight int
+	switch err := row.Scan(&weight); err {
+	case nil:
+		return weight, nil
+	case sql.ErrNoRows:
+		return 1, nil
+	default:
+		r.l.Info(err.Error(), document)
+		return 0, err
+	}
+}
@@ -0,0 +1,188 @@
+package adapters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+)
+
+var rosterMock = associate.Roster{
+	ID:   "string",
+	Name: "string",
+	Members: []associate.Member{
+		{AssociateID: "string", Document: "11222333000181", Weight: 4},
+		{Document: "52998224725", Weight: 1},
+	},
+	Size:     2,
+	Creation: time.Now(),
+}
+
+func TestInsertRoster(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("inserts the roster and its members in a transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO rosters").
+			WithArgs(rosterMock.ID, rosterMock.Name, rosterMock.Size, rosterMock.Creation).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		prepared := mock.ExpectPrepare("INSERT INTO roster_members")
+		prepared.ExpectExec().
+			WithArgs(rosterMock.ID, "11222333000181", "string", 4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		prepared.ExpectExec().
+			WithArgs(rosterMock.ID, "52998224725", nil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.InsertRoster(rosterMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("rolls back if a member fails", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO rosters").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare("INSERT INTO roster_members").ExpectExec().WillReturnError(want)
+		mock.ExpectRollback()
+
+		got := repo.InsertRoster(rosterMock)
+
+		assertValue(t, got, want)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+}
+
+func TestFindRoster(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	rosterRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "size", "creation"}).
+			AddRow(rosterMock.ID, rosterMock.Name, rosterMock.Size, rosterMock.Creation)
+	}
+
+	t.Run("returns the roster with its members", func(t *testing.T) {
+		mock.ExpectQuery("SELECT id, name, size, creation FROM rosters WHERE id").
+			WithArgs(rosterMock.ID).
+			WillReturnRows(rosterRows())
+		mock.ExpectQuery("SELECT document, associateID, weight FROM roster_members WHERE rosterID").
+			WithArgs(rosterMock.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"document", "associateID", "weight"}).
+				AddRow("11222333000181", "string", 4).
+				AddRow("52998224725", nil, 1))
+
+		got, err := repo.FindRoster(rosterMock.ID)
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got, rosterMock) {
+			t.Errorf("want %v, got %v", rosterMock, got)
+		}
+	})
+
+	t.Run("returns the roster size", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM rosters WHERE id").
+			WithArgs(rosterMock.ID).
+			WillReturnRows(rosterRows())
+
+		got, err := repo.FindRosterSize(rosterMock.ID)
+
+		assertValue(t, err, nil)
+		assertValue(t, got, 2)
+	})
+
+	t.Run("not founding the key, return a Roster not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM rosters WHERE id").
+			WithArgs(rosterMock.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "size", "creation"}))
+
+		_, got := repo.FindRosterSize(rosterMock.ID)
+
+		assertValue(t, errors.Is(got, associate.ErrRosterNotFound), true)
+	})
+}
+
+func TestIsRosterMember(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("reports whether the document is listed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT EXISTS (.+) FROM roster_members WHERE rosterID = \\$1 AND document = \\$2").
+			WithArgs("aRoster", "52998224725").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		got, err := repo.IsRosterMember("aRoster", "52998224725")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, true)
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT EXISTS").WillReturnError(want)
+
+		_, got := repo.IsRosterMember("aRoster", "52998224725")
+
+		assertValue(t, got, want)
+	})
+}
+
+func TestFindVoterWeight(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the weight of the roster member", func(t *testing.T) {
+		mock.ExpectQuery("SELECT weight FROM roster_members WHERE rosterID = \\$1 AND document = \\$2").
+			WithArgs("aRoster", "52998224725").
+			WillReturnRows(sqlmock.NewRows([]string{"weight"}).AddRow(4))
+
+		got, err := repo.FindVoterWeight("aRoster", "52998224725")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, 4)
+	})
+
+	t.Run("returns the weight of the registered associate without a roster", func(t *testing.T) {
+		mock.ExpectQuery("SELECT weight FROM associates WHERE document = \\$1").
+			WithArgs("52998224725").
+			WillReturnRows(sqlmock.NewRows([]string{"weight"}).AddRow(7))
+
+		got, err := repo.FindVoterWeight("", "52998224725")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, 7)
+	})
+
+	t.Run("unknown documents weigh one", func(t *testing.T) {
+		mock.ExpectQuery("SELECT weight FROM associates").
+			WillReturnRows(sqlmock.NewRows([]string{"weight"}))
+
+		got, err := repo.FindVoterWeight("", "52998224725")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, 1)
+	})
+
+	t.Run("proxys the error from the sql db", func(t *testing.T) {
+		want := errors.New("an error")
+		mock.ExpectQuery("SELECT weight FROM roster_members").WillReturnError(want)
+
+		_, got := repo.FindVoterWeight("aRoster", "52998224725")
+
+		assertValue(t, got, want)
+	})
+}
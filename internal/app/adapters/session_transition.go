@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+var transitSessionStatement = `
+	UPDATE sessions
+		SET duration = $2, state = $3, resultPublished = resultPublished AND NOT $4
+		WHERE id = $1 AND state = $5 AND duration = $6`
+
+var insertTransitionStatement = `
+	INSERT INTO session_transitions (id, sessionID, action, previousState, state, reason, closesAt, creation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+// InsertTransition stores the changed session along with the transition
+// that changed it, in a single transaction. The session is changed only
+// if it is still stored as it was found, ErrBadTransition otherwise, so
+// concurrent changes are not lost. Reopened sessions get their result
+// published again
+func (r *SQLRepository) InsertTransition(found, s session.Session, t session.Transition) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		transitSessionStatement,
+		s.ID,
+		s.Duration,
+		s.State,
+		t.Action == session.ReopenAction,
+		found.State,
+		found.Duration,
+	)
+	if err != nil {
+		r.l.Info(err.Error(), s.ID)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", session.ErrBadTransition, s.ID)
+	}
+
+	_, err = tx.Exec(
+		insertTransitionStatement,
+		t.ID,
+		t.SessionID,
+		t.Action,
+		t.From,
+		t.To,
+		nullable(t.Reason),
+		t.Closes,
+		t.Creation,
+	)
+	if err != nil {
+		r.l.Info(err.Error(), s.ID)
+		return err
+	}
+	return tx.Commit()
+}
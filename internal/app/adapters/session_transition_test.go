@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+var transitionMock = session.Transition{
+	ID:        "string",
+	SessionID: "string",
+	Action:    session.ReopenAction,
+	From:      session.StateClosed,
+	To:        session.StateOpen,
+	Reason:    "string",
+	Closes:    time.Now(),
+	Creation:  time.Now(),
+}
+
+func TestInsertTransition(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	found := sessionMock
+	found.State, found.Duration = session.StateClosed, time.Minute
+
+	t.Run("updates the session and records the transition", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE sessions SET duration = \\$2, state = \\$3(.+) WHERE id = \\$1 AND state = \\$5 AND duration = \\$6").
+			WithArgs(sessionMock.ID, sessionMock.Duration, sessionMock.State, true, found.State, found.Duration).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO session_transitions").
+			WithArgs(
+				transitionMock.ID,
+				transitionMock.SessionID,
+				transitionMock.Action,
+				transitionMock.From,
+				transitionMock.To,
+				transitionMock.Reason,
+				transitionMock.Closes,
+				transitionMock.Creation,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.InsertTransition(found, sessionMock, transitionMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a bad transition if the session changed since it was found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE sessions").
+			WithArgs(sessionMock.ID, sessionMock.Duration, sessionMock.State, true, found.State, found.Duration).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		got := repo.InsertTransition(found, sessionMock, transitionMock)
+
+		assertValue(t, errors.Is(got, session.ErrBadTransition), true)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+	"github.com/lib/pq"
+)
+
+var findVoteStatement = `
+	SELECT associateID, sessionID, document, vote, weight, ranking, selections, creation
+		FROM votes
+		WHERE sessionID = $1 AND associateID = $2`
+
+// FindVote finds the vote of an associate in a session, with its ranking
+// or selections
+func (r *SQLRepository) FindVote(sessionID, associateID string) (vote.Vote, error) {
+	row := r.db.QueryRow(findVoteStatement, sessionID, associateID)
+
+	var v vote.Vote
+	err := row.Scan(
+		&v.AssociateID,
+		&v.SessionID,
+		&v.Document,
+		&v.Vote,
+		&v.Weight,
+		pq.Array(&v.Ranking),
+		pq.Array(&v.Selections),
+		&v.Creation,
+	)
+	switch err {
+	case nil:
+		return v, nil
+	case sql.ErrNoRows:
+		r.l.Info(err.Error(), associateID)
+		return vote.Vote{}, fmt.Errorf("%w: %s", vote.ErrVoteNotFound, associateID)
+	default:
+		r.l.Info(err.Error(), associateID)
+		if hasCode(err, invalidTextRepresentation) {
+			return vote.Vote{}, wrap(vote.ErrVoteNotFound, err)
+		}
+		return vote.Vote{}, err
+	}
+}
+
+var updateVoteStatement = `
+	UPDATE votes
+		SET vote = $3, creation = $4, proxyDocument = NULL, ranking = $5, selections = $6
+		WHERE associateID = $1 AND sessionID = $2`
+
+// UpdateVote replaces the vote, with its ranking or selections, and
+// records the change, a changed vote is no longer the one cast by a proxy
+func (r *SQLRepository) UpdateVote(c vote.Change) error {
+	return r.changeVote(
+		c,
+		updateVoteStatement,
+		c.AssociateID,
+		c.SessionID,
+		c.Vote,
+		c.Creation,
+		pq.Array(c.Ranking),
+		pq.Array(c.Selections),
+	)
+}
+
+var deleteVoteStatement = `
+	DELETE FROM votes
+		WHERE associateID = $1 AND sessionID = $2`
+
+// DeleteVote removes the vote and records the retraction
+func (r *SQLRepository) DeleteVote(c vote.Change) error {
+	return r.changeVote(c, deleteVoteStatement, c.AssociateID, c.SessionID)
+}
+
+var insertVoteChangeStatement = `
+	INSERT INTO vote_changes (associateID, sessionID, document, action, previous, previousRanking, previousSelections, vote, ranking, selections, creation)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+// changeVote runs the statement changing the vote along with the insertion
+// of the change into the history, in a single transaction
+func (r *SQLRepository) changeVote(c vote.Change, statement string, args ...interface{}) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(statement, args...)
+	if err != nil {
+		r.l.Info(err.Error(), c.AssociateID)
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: %s", vote.ErrVoteNotFound, c.AssociateID)
+	}
+
+	_, err = tx.Exec(
+		insertVoteChangeStatement,
+		c.AssociateID,
+		c.SessionID,
+		c.Document,
+		c.Action,
+		c.Previous,
+		pq.Array(c.PreviousRanking),
+		pq.Array(c.PreviousSelections),
+		nullable(c.Vote),
+		pq.Array(c.Ranking),
+		pq.Array(c.Selections),
+		c.Creation,
+	)
+	if err != nil {
+		r.l.Info(err.Error(), c.AssociateID)
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,153 @@
+package adapters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+var changeMock = vote.Change{
+	AssociateID:     "string",
+	SessionID:       "string",
+	Document:        "string",
+	Action:          vote.ActionChange,
+	Previous:        "S",
+	PreviousRanking: []string{"S", "N"},
+	Vote:            "N",
+	Ranking:         []string{"N", "S"},
+	Creation:        time.Now(),
+}
+
+func TestFindVote(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("returns the vote of the associate", func(t *testing.T) {
+		want := vote.Vote{AssociateID: "anID", SessionID: "aSession", Document: "doc", Vote: "S", Weight: 2, Creation: time.Now()}
+		mock.ExpectQuery("SELECT (.+) FROM votes WHERE sessionID = \\$1 AND associateID = \\$2").
+			WithArgs("aSession", "anID").
+			WillReturnRows(sqlmock.NewRows([]string{"associateID", "sessionID", "document", "vote", "weight", "ranking", "selections", "creation"}).
+				AddRow(want.AssociateID, want.SessionID, want.Document, want.Vote, want.Weight, nil, nil, want.Creation))
+
+		got, err := repo.FindVote("aSession", "anID")
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("returns the ranking and selections of the vote", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM votes").
+			WillReturnRows(sqlmock.NewRows([]string{"associateID", "sessionID", "document", "vote", "weight", "ranking", "selections", "creation"}).
+				AddRow("anID", "aSession", "doc", "S", 1, "{S,N}", "{S}", time.Now()))
+
+		got, err := repo.FindVote("aSession", "anID")
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got.Ranking, []string{"S", "N"}) || !reflect.DeepEqual(got.Selections, []string{"S"}) {
+			t.Errorf("got %v and %v", got.Ranking, got.Selections)
+		}
+	})
+
+	t.Run("not founding the vote, return a Vote not found", func(t *testing.T) {
+		mock.ExpectQuery("SELECT (.+) FROM votes").
+			WillReturnRows(sqlmock.NewRows([]string{"associateID", "sessionID", "document", "vote", "weight", "ranking", "selections", "creation"}))
+
+		_, got := repo.FindVote("aSession", "anID")
+
+		assertValue(t, errors.Is(got, vote.ErrVoteNotFound), true)
+	})
+}
+
+func TestUpdateVote(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("updates the vote and records the change", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE votes SET vote = \\$3, creation = \\$4, proxyDocument = NULL, ranking = \\$5, selections = \\$6").
+			WithArgs(changeMock.AssociateID, changeMock.SessionID, changeMock.Vote, changeMock.Creation, "{\"N\",\"S\"}", nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO vote_changes").
+			WithArgs(
+				changeMock.AssociateID,
+				changeMock.SessionID,
+				changeMock.Document,
+				changeMock.Action,
+				changeMock.Previous,
+				"{\"S\",\"N\"}",
+				nil,
+				changeMock.Vote,
+				"{\"N\",\"S\"}",
+				nil,
+				changeMock.Creation,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateVote(changeMock)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+
+	t.Run("returns a vote not found if nothing was updated", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE votes").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		got := repo.UpdateVote(changeMock)
+
+		assertValue(t, errors.Is(got, vote.ErrVoteNotFound), true)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+}
+
+func TestDeleteVote(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	repo := SQLRepository{db: db, l: &loggerStub{}}
+	defer db.Close()
+
+	t.Run("deletes the vote and records the retraction", func(t *testing.T) {
+		retraction := changeMock
+		retraction.Action, retraction.Vote, retraction.Ranking = vote.ActionRetraction, "", nil
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM votes WHERE associateID = \\$1 AND sessionID = \\$2").
+			WithArgs(retraction.AssociateID, retraction.SessionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO vote_changes").
+			WithArgs(
+				retraction.AssociateID,
+				retraction.SessionID,
+				retraction.Document,
+				vote.ActionRetraction,
+				retraction.Previous,
+				"{\"S\",\"N\"}",
+				nil,
+				nil,
+				nil,
+				nil,
+				retraction.Creation,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteVote(retraction)
+
+		assertValue(t, err, nil)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("SQL expectations failed: %s", err)
+		}
+	})
+}
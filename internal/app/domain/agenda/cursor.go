@@ -0,0 +1,39 @@
+package agenda
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrBadCursor represents an error caused by a malformed pagination cursor
+var ErrBadCursor = errors.New("Invalid pagination cursor")
+
+func encodeCursor(a Agenda) string {
+	raw := a.Creation.UTC().Format(time.RFC3339Nano) + "|" + a.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrBadCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, ErrBadCursor
+	}
+	if _, err := uuid.Parse(parts[1]); err != nil {
+		return nil, ErrBadCursor
+	}
+
+	creation, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, ErrBadCursor
+	}
+	return &Cursor{Creation: creation, ID: parts[1]}, nil
+}
@@ -1,25 +1,64 @@
 package agenda
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"strings"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
+	"github.com/google/uuid"
+)
+
+const (
+	maxOptionIDLength = 50
+	defaultPageLimit  = 20
+	maxPageLimit      = 100
+)
+
+var (
+	// ErrAgendaNotFound represents an error caused by an unknown agenda
+	ErrAgendaNotFound = errors.New("Agenda not found")
+	// ErrTooFewOptions represents an error caused by an agenda with less than two options
+	ErrTooFewOptions = errors.New("An agenda must declare at least two options")
+	// ErrBadOptionFormat represents an error caused by an option with an invalid ID
+	ErrBadOptionFormat = errors.New("Option IDs must be non empty, up to 50 characters and without commas")
+	// ErrDuplicateOption represents an error caused by an option declared twice
+	ErrDuplicateOption = errors.New("Duplicate option")
+	// ErrReservedOption represents an error caused by an option using the abstention value
+	ErrReservedOption = errors.New("Option ID 'abstain' is reserved for abstentions")
+	// ErrBadFilter represents an error caused by an invalid listing filter
+	ErrBadFilter = errors.New("Invalid filter. Status must be 'new', 'voting' or 'voted', sort must be 'creation' or '-creation' and limit up to 100")
+)
 
 // NewAgendaService creates and returns an agenda service
 func NewAgendaService(r Repository) Service {
 	return &agendaService{
-		repo: r,
+		repo:  r,
+		clock: clock.System{},
 	}
 }
 
 type agendaService struct {
-	repo Repository
+	repo  Repository
+	clock clock.Clock
 }
 
-// CreateAgenda creates an agenda em stores it
-func (s *agendaService) CreateAgenda(description string) (Agenda, error) {
+// CreateAgenda creates an agenda em stores it, agendas without
+// options are created with the default yes/no options
+func (s *agendaService) CreateAgenda(description string, options []Option) (Agenda, error) {
+	if len(options) == 0 {
+		options = DefaultOptions
+	}
+	if err := validateOptions(options); err != nil {
+		return Agenda{}, err
+	}
+
 	id := uuid.New()
 
 	agenda := Agenda{
 		ID:          id.String(),
 		Description: description,
+		Options:     options,
+		Creation:    s.clock.Now(),
 	}
 
 	err := s.repo.InsertAgenda(agenda)
@@ -37,3 +76,85 @@ func (s *agendaService) FindAgenda(id string) (Agenda, error) {
 	}
 	return agenda, nil
 }
+
+// ListAgendas returns a page of agendas sorted by creation
+func (s *agendaService) ListAgendas(f Filter) (Page, error) {
+	q, err := s.buildQuery(f)
+	if err != nil {
+		return Page{}, err
+	}
+
+	limit := q.Limit
+	q.Limit++
+	agendas, err := s.repo.ListAgendas(q)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Agendas: agendas}
+	if len(agendas) > limit {
+		page.Agendas = agendas[:limit]
+		page.NextCursor = encodeCursor(page.Agendas[limit-1])
+	}
+	return page, nil
+}
+
+func (s *agendaService) buildQuery(f Filter) (Query, error) {
+	q := Query{
+		Now:         s.clock.Now(),
+		Status:      f.Status,
+		Description: f.Description,
+		Limit:       f.Limit,
+	}
+
+	switch f.Status {
+	case "", StatusNew, StatusVoting, StatusVoted:
+	default:
+		return Query{}, ErrBadFilter
+	}
+
+	switch f.Sort {
+	case "", "creation":
+	case "-creation":
+		q.Descending = true
+	default:
+		return Query{}, ErrBadFilter
+	}
+
+	switch {
+	case f.Limit == 0:
+		q.Limit = defaultPageLimit
+	case f.Limit < 0 || f.Limit > maxPageLimit:
+		return Query{}, ErrBadFilter
+	}
+
+	if f.Cursor != "" {
+		after, err := decodeCursor(f.Cursor)
+		if err != nil {
+			return Query{}, err
+		}
+		q.After = after
+	}
+	return q, nil
+}
+
+func validateOptions(options []Option) error {
+	if len(options) < 2 {
+		return ErrTooFewOptions
+	}
+
+	seen := map[string]bool{}
+	for _, o := range options {
+		if o.ID == "" || len(o.ID) > maxOptionIDLength || strings.Contains(o.ID, ",") {
+			return ErrBadOptionFormat
+		}
+		if o.ID == Abstention {
+			return ErrReservedOption
+		}
+		if seen[o.ID] {
+			return ErrDuplicateOption
+		}
+		seen[o.ID] = true
+	}
+	return nil
+}
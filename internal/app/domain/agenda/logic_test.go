@@ -1,20 +1,25 @@
 package agenda
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
 )
 
 type AgendaRepoStub struct {
-	store map[string]Agenda
+	store     map[string]Agenda
+	lastQuery Query
 }
 
 func (r *AgendaRepoStub) FindAgenda(ID string) (Agenda, error) {
 	key, ok := r.store[ID]
 	if ok == false {
-		return Agenda{}, errors.New("Agenda not found")
+		return Agenda{}, ErrAgendaNotFound
 	}
 	return key, nil
 }
@@ -27,13 +32,28 @@ func (r *AgendaRepoStub) InsertAgenda(a Agenda) error {
 	return nil
 }
 
+func (r *AgendaRepoStub) ListAgendas(q Query) ([]Agenda, error) {
+	r.lastQuery = q
+	if q.Description == "error" {
+		return nil, errors.New("ops, there was an error")
+	}
+	agendas := []Agenda{}
+	for i := 0; i < 3 && i < q.Limit; i++ {
+		agendas = append(agendas, Agenda{
+			ID:       fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+			Creation: q.Now.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return agendas, nil
+}
+
 func TestCreateAgenda(t *testing.T) {
 	store := map[string]Agenda{}
-	repo := AgendaRepoStub{store}
-	service := agendaService{&repo}
+	repo := AgendaRepoStub{store: store}
+	service := agendaService{&repo, clock.System{}}
 	t.Run("Returns an agenda", func(t *testing.T) {
 		description := "uma descricao da pauta"
-		got, _ := service.CreateAgenda(description)
+		got, _ := service.CreateAgenda(description, nil)
 		want := Agenda{}
 
 		assertType(t, got, want)
@@ -41,19 +61,64 @@ func TestCreateAgenda(t *testing.T) {
 		assertString(t, got.Description, description)
 	})
 	t.Run("Returns the error if there was any error", func(t *testing.T) {
-		_, got := service.CreateAgenda("error")
+		_, got := service.CreateAgenda("error", nil)
 		want := errors.New("error")
 
 		assertType(t, got, want)
 	})
 }
 
+func TestCreateAgendaOptions(t *testing.T) {
+	store := map[string]Agenda{}
+	repo := AgendaRepoStub{store: store}
+	service := agendaService{&repo, clock.System{}}
+	t.Run("Uses the default options if none was informed", func(t *testing.T) {
+		got, _ := service.CreateAgenda("description", nil)
+
+		if !reflect.DeepEqual(got.Options, DefaultOptions) {
+			t.Errorf("got %v want %v", got.Options, DefaultOptions)
+		}
+	})
+	t.Run("Keeps the informed options", func(t *testing.T) {
+		options := []Option{
+			{ID: "alice", Description: "Alice"},
+			{ID: "bob", Description: "Bob"},
+			{ID: "carol", Description: "Carol"},
+		}
+		got, _ := service.CreateAgenda("description", options)
+
+		if !reflect.DeepEqual(got.Options, options) {
+			t.Errorf("got %v want %v", got.Options, options)
+		}
+	})
+	t.Run("Returns an error if there is only one option", func(t *testing.T) {
+		_, err := service.CreateAgenda("description", []Option{{ID: "alone"}})
+
+		assertError(t, err, ErrTooFewOptions)
+	})
+	t.Run("Returns an error if an option is duplicated", func(t *testing.T) {
+		_, err := service.CreateAgenda("description", []Option{{ID: "a"}, {ID: "a"}})
+
+		assertError(t, err, ErrDuplicateOption)
+	})
+	t.Run("Returns an error if an option has an invalid ID", func(t *testing.T) {
+		_, err := service.CreateAgenda("description", []Option{{ID: "a,b"}, {ID: ""}})
+
+		assertError(t, err, ErrBadOptionFormat)
+	})
+	t.Run("Returns an error if an option uses the abstention value", func(t *testing.T) {
+		_, err := service.CreateAgenda("description", []Option{{ID: "yes"}, {ID: Abstention}})
+
+		assertError(t, err, ErrReservedOption)
+	})
+}
+
 func TestFindAgenda(t *testing.T) {
 	store := map[string]Agenda{}
-	repo := AgendaRepoStub{store}
-	service := agendaService{&repo}
+	repo := AgendaRepoStub{store: store}
+	service := agendaService{&repo, clock.System{}}
 	t.Run("Returns an agenda", func(t *testing.T) {
-		want, _ := service.CreateAgenda("description")
+		want, _ := service.CreateAgenda("description", nil)
 
 		got, _ := service.FindAgenda(want.ID)
 
@@ -70,6 +135,80 @@ func TestFindAgenda(t *testing.T) {
 	})
 }
 
+func TestListAgendas(t *testing.T) {
+	repo := AgendaRepoStub{store: map[string]Agenda{}}
+	service := agendaService{&repo, clock.System{}}
+	t.Run("Uses the default limit asking one more to detect the next page", func(t *testing.T) {
+		service.ListAgendas(Filter{})
+
+		assertValue(t, repo.lastQuery.Limit, defaultPageLimit+1)
+		assertValue(t, repo.lastQuery.Descending, false)
+	})
+	t.Run("Returns a next cursor if there are more agendas", func(t *testing.T) {
+		got, _ := service.ListAgendas(Filter{Limit: 2})
+
+		assertValue(t, len(got.Agendas), 2)
+		if got.NextCursor == "" {
+			t.Errorf("want a next cursor")
+		}
+	})
+	t.Run("Does not return a next cursor on the last page", func(t *testing.T) {
+		got, _ := service.ListAgendas(Filter{Limit: 3})
+
+		assertValue(t, len(got.Agendas), 3)
+		assertString(t, got.NextCursor, "")
+	})
+	t.Run("Continues after the informed cursor", func(t *testing.T) {
+		page, _ := service.ListAgendas(Filter{Limit: 1})
+
+		service.ListAgendas(Filter{Limit: 1, Cursor: page.NextCursor})
+
+		assertString(t, repo.lastQuery.After.ID, page.Agendas[0].ID)
+		if !repo.lastQuery.After.Creation.Equal(page.Agendas[0].Creation) {
+			t.Errorf("got %v want %v", repo.lastQuery.After.Creation, page.Agendas[0].Creation)
+		}
+	})
+	t.Run("Sorts descending", func(t *testing.T) {
+		service.ListAgendas(Filter{Sort: "-creation", Status: StatusVoting, Description: "text"})
+
+		assertValue(t, repo.lastQuery.Descending, true)
+		assertString(t, repo.lastQuery.Status, StatusVoting)
+		assertString(t, repo.lastQuery.Description, "text")
+	})
+	t.Run("Returns a bad filter error for unknown filters", func(t *testing.T) {
+		for _, f := range []Filter{{Status: "unknown"}, {Sort: "description"}, {Limit: 1000}} {
+			_, err := service.ListAgendas(f)
+
+			assertError(t, err, ErrBadFilter)
+		}
+	})
+	t.Run("Returns a bad cursor error for malformed cursors", func(t *testing.T) {
+		_, err := service.ListAgendas(Filter{Cursor: "not a cursor"})
+
+		assertError(t, err, ErrBadCursor)
+	})
+	t.Run("Returns a bad cursor error for cursors whose ID is not a UUID", func(t *testing.T) {
+		raw := time.Now().UTC().Format(time.RFC3339Nano) + "|not-an-id"
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(raw))
+
+		_, err := service.ListAgendas(Filter{Cursor: cursor})
+
+		assertError(t, err, ErrBadCursor)
+	})
+	t.Run("Returns the error if there was any error", func(t *testing.T) {
+		_, err := service.ListAgendas(Filter{Description: "error"})
+
+		assertString(t, err.Error(), "ops, there was an error")
+	})
+}
+
+func assertValue(t *testing.T, got, want interface{}) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
 func assertType(t *testing.T, got, want interface{}) {
 	t.Helper()
 	if reflect.TypeOf(got) != reflect.TypeOf(want) {
@@ -84,6 +223,13 @@ func assertString(t *testing.T, got, want string) {
 	}
 }
 
+func assertError(t *testing.T, got, want error) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
 func assertTime(t *testing.T, got, want time.Time) {
 	t.Helper()
 	if got.Round(time.Second) != want.Round(time.Second) {
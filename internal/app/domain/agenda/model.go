@@ -1,7 +1,79 @@
 package agenda
 
-// Agenda Representation of a meeting agenda
+import "time"
+
+// Agenda statuses, derived from the agenda sessions
+const (
+	StatusNew    = "new"
+	StatusVoting = "voting"
+	StatusVoted  = "voted"
+)
+
+// Agenda Representation of a meeting agenda, the status is only
+// filled when listing agendas
 type Agenda struct {
 	ID          string
 	Description string
+	Options     []Option
+	Creation    time.Time
+	Status      string
+}
+
+// Option Representation of a ballot option declared by an agenda
+type Option struct {
+	ID          string
+	Description string
+}
+
+// HasOption Returns if the agenda declares the option
+func (a *Agenda) HasOption(id string) bool {
+	for _, o := range a.Options {
+		if o.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Abstention Vote value of an associate present but abstaining, it is
+// accepted by every agenda and can not be declared as an option
+const Abstention = "abstain"
+
+// DefaultOptions Options used by agendas that do not declare any
+var DefaultOptions = []Option{
+	{ID: "S", Description: "Sim"},
+	{ID: "N", Description: "Não"},
+}
+
+// Filter Set of parameters to list agendas, sort may be "creation" or
+// "-creation" for descending order
+type Filter struct {
+	Status      string
+	Description string
+	Sort        string
+	Cursor      string
+	Limit       int
+}
+
+// Page Representation of a page of agendas, the next cursor is empty
+// on the last page
+type Page struct {
+	Agendas    []Agenda
+	NextCursor string
+}
+
+// Query Set of parameters used by the repository to list agendas
+type Query struct {
+	Now         time.Time
+	Status      string
+	Description string
+	Descending  bool
+	After       *Cursor
+	Limit       int
+}
+
+// Cursor Position of an agenda inside a listing
+type Cursor struct {
+	Creation time.Time
+	ID       string
 }
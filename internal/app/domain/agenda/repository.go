@@ -4,4 +4,5 @@ package agenda
 type Repository interface {
 	FindAgenda(string) (Agenda, error)
 	InsertAgenda(Agenda) error
+	ListAgendas(Query) ([]Agenda, error)
 }
@@ -2,6 +2,7 @@ package agenda
 
 // Service describes the agenda service interface
 type Service interface {
-	CreateAgenda(string) (Agenda, error)
+	CreateAgenda(string, []Option) (Agenda, error)
 	FindAgenda(string) (Agenda, error)
+	ListAgendas(Filter) (Page, error)
 }
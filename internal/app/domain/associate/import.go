@@ -0,0 +1,176 @@
+package associate
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+var (
+	// ErrBadImportRow represents an error caused by a csv row missing columns
+	ErrBadImportRow = errors.New("Rows must have the id and document columns")
+	// ErrMalformedImportRow represents an error caused by a row that is not valid csv
+	ErrMalformedImportRow = errors.New("Rows must be valid csv, quoted fields must be closed and followed by a comma")
+)
+
+// rowErrors are the errors concerning only the row, reported as the
+// reason of its rejection. Errors wrapping them are reported by the
+// domain message alone, keeping the causes away from the clients
+var rowErrors = []error{
+	ErrBadImportRow,
+	ErrMalformedImportRow,
+	ErrBadAssociate,
+	ErrDuplicateAssociate,
+	vote.ErrInvalidDocument,
+}
+
+// ImportRow Representation of the outcome of one imported csv row
+type ImportRow struct {
+	Row      int
+	ID       string
+	Document string
+	Accepted bool
+	Reason   string
+}
+
+// ImportSummary Representation of the totals of an import
+type ImportSummary struct {
+	Accepted int
+	Rejected int
+}
+
+type columns struct {
+	id, document, name, weight int
+}
+
+// defaultColumns are used when the csv has no header
+var defaultColumns = columns{id: 0, document: 1, name: 2, weight: 3}
+
+// byteOrderMark is written by spreadsheet tools at the start of utf-8 files
+const byteOrderMark = "\ufeff"
+
+// ImportCSV streams a csv of associates into the service, one row at a
+// time, reporting the outcome of each row as soon as it is known. Rows
+// are "id,document[,name]", a header naming the columns may reorder them.
+// Invalid rows are rejected and reported, the import only stops on errors
+// of the service itself or of the report
+func ImportCSV(s Service, r io.Reader, report func(ImportRow) error) (ImportSummary, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	var summary ImportSummary
+	cols := defaultColumns
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return summary, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			err = report(rejectRow(&summary, ImportRow{Row: n}, ErrMalformedImportRow))
+			if err != nil {
+				return summary, err
+			}
+			continue
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		if n == 1 && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], byteOrderMark)
+			if header, ok := parseHeader(record); ok {
+				cols = header
+				continue
+			}
+		}
+
+		row, err := importRecord(s, cols, record)
+		row.Row = n
+		if err == nil {
+			summary.Accepted++
+			row.Accepted = true
+		} else if reason := rowError(err); reason != nil {
+			row = rejectRow(&summary, row, reason)
+		} else {
+			return summary, err
+		}
+
+		if err := report(row); err != nil {
+			return summary, err
+		}
+	}
+}
+
+func importRecord(s Service, cols columns, record []string) (ImportRow, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := ImportRow{ID: field(cols.id), Document: field(cols.document)}
+	if len(record) <= cols.id || len(record) <= cols.document {
+		return row, ErrBadImportRow
+	}
+
+	weight := 0
+	if w := field(cols.weight); w != "" {
+		var err error
+		if weight, err = strconv.Atoi(w); err != nil {
+			return row, ErrBadAssociate
+		}
+	}
+
+	a, err := s.ImportAssociate(row.ID, Params{Document: row.Document, Name: field(cols.name), Weight: weight})
+	if err != nil {
+		return row, err
+	}
+	row.Document = a.Document
+	return row, nil
+}
+
+// parseHeader recognizes a header row, it must name the id and document
+// columns and may name the name and weight columns
+func parseHeader(record []string) (columns, bool) {
+	cols := columns{id: -1, document: -1, name: -1, weight: -1}
+	for i, name := range record {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "id":
+			cols.id = i
+		case "document":
+			cols.document = i
+		case "name":
+			cols.name = i
+		case "weight":
+			cols.weight = i
+		}
+	}
+	return cols, cols.id >= 0 && cols.document >= 0
+}
+
+// rowError returns the domain error the error matches when it concerns
+// only the row, nil otherwise
+func rowError(err error) error {
+	for _, e := range rowErrors {
+		if errors.Is(err, e) {
+			return e
+		}
+	}
+	return nil
+}
+
+func rejectRow(summary *ImportSummary, row ImportRow, reason error) ImportRow {
+	summary.Rejected++
+	row.Accepted = false
+	row.Reason = reason.Error()
+	return row
+}
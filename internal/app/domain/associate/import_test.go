@@ -0,0 +1,123 @@
+package associate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func importCSV(t *testing.T, service Service, csv string) ([]ImportRow, ImportSummary, error) {
+	t.Helper()
+	var rows []ImportRow
+	summary, err := ImportCSV(service, strings.NewReader(csv), func(r ImportRow) error {
+		rows = append(rows, r)
+		return nil
+	})
+	return rows, summary, err
+}
+
+func TestImportCSV(t *testing.T) {
+	newService := func() (Service, *AssociateRepoStub) {
+		repo := AssociateRepoStub{store: map[string]Associate{}}
+		return &associateService{&repo, &ClockStub{RightNow: time.Now()}}, &repo
+	}
+	t.Run("Imports every valid row", func(t *testing.T) {
+		service, repo := newService()
+
+		rows, summary, err := importCSV(t, service, "A1,529.982.247-25,First\nA2,11222333000181\n")
+
+		assertValue(t, err, nil)
+		assertValue(t, summary, ImportSummary{Accepted: 2})
+		assertValue(t, len(rows), 2)
+		assertValue(t, rows[0], ImportRow{Row: 1, ID: "A1", Document: validCPF, Accepted: true})
+		assertValue(t, repo.store["A1"].Name, "First")
+		assertValue(t, repo.store["A2"].Active, true)
+	})
+	t.Run("Uses the header to find the columns", func(t *testing.T) {
+		service, repo := newService()
+
+		rows, summary, err := importCSV(t, service, "name,document,id\nFirst,"+validCPF+",A1\n")
+
+		assertValue(t, err, nil)
+		assertValue(t, summary, ImportSummary{Accepted: 1})
+		assertValue(t, rows[0].Row, 2)
+		assertValue(t, repo.store["A1"].Name, "First")
+	})
+	t.Run("Ignores a byte order mark before the header", func(t *testing.T) {
+		service, repo := newService()
+
+		rows, summary, err := importCSV(t, service, "\ufeffid,document,name\nA1,"+validCPF+",First\n")
+
+		assertValue(t, err, nil)
+		assertValue(t, summary, ImportSummary{Accepted: 1})
+		assertValue(t, rows[0].Row, 2)
+		assertValue(t, repo.store["A1"].Name, "First")
+	})
+	t.Run("Imports the weight column", func(t *testing.T) {
+		service, repo := newService()
+
+		rows, _, _ := importCSV(t, service, "A1,"+validCPF+",First,7\n")
+		_, summary, _ := importCSV(t, service, "weight,id,document\n3,A2,"+validCNPJ+"\nx,A3,"+validCNPJ+"\n")
+
+		assertValue(t, rows[0].Accepted, true)
+		assertValue(t, repo.store["A1"].Weight, 7)
+		assertValue(t, repo.store["A2"].Weight, 3)
+		assertValue(t, summary, ImportSummary{Accepted: 1, Rejected: 1})
+	})
+	t.Run("Rejects invalid rows and keeps importing", func(t *testing.T) {
+		service, _ := newService()
+
+		rows, summary, err := importCSV(t, service, strings.Join([]string{
+			"id,document",
+			"A1," + validCPF,
+			"A2,12345678900",
+			"A3",
+			"," + validCNPJ,
+			"A4," + validCPF,
+			`A5,"` + validCNPJ + `"x`,
+			"A6," + validCNPJ,
+		}, "\n"))
+
+		assertValue(t, err, nil)
+		assertValue(t, summary, ImportSummary{Accepted: 2, Rejected: 5})
+		for i, accepted := range []bool{true, false, false, false, false, false, true} {
+			assertValue(t, rows[i].Accepted, accepted)
+			assertValue(t, rows[i].Row, i+2)
+		}
+		assertValue(t, rows[1].Reason, "Invalid document. Must be a valid CPF or CNPJ")
+		assertValue(t, rows[2].Reason, ErrBadImportRow.Error())
+		assertValue(t, rows[3].Reason, ErrBadAssociate.Error())
+		assertValue(t, rows[4].Reason, ErrDuplicateAssociate.Error())
+		assertValue(t, rows[5].Reason, ErrMalformedImportRow.Error())
+	})
+	t.Run("Reports only the domain message of wrapped errors", func(t *testing.T) {
+		service, _ := newService()
+
+		rows, summary, err := importCSV(t, service, "wrapped,"+validCPF+"\n")
+
+		assertValue(t, err, nil)
+		assertValue(t, summary, ImportSummary{Rejected: 1})
+		assertValue(t, rows[0].Reason, ErrDuplicateAssociate.Error())
+	})
+	t.Run("Stops on errors of the service", func(t *testing.T) {
+		service, _ := newService()
+
+		rows, summary, err := importCSV(t, service, "A1,"+validCPF+"\nerror,"+validCNPJ+"\nA3,"+validCNPJ)
+
+		assertValue(t, err.Error(), "ops, there was an error")
+		assertValue(t, summary, ImportSummary{Accepted: 1})
+		assertValue(t, len(rows), 1)
+	})
+	t.Run("Stops on errors of the report", func(t *testing.T) {
+		service, _ := newService()
+		want := errors.New("client gone")
+
+		summary, err := ImportCSV(service, strings.NewReader("A1,"+validCPF+"\nA2,"+validCNPJ), func(ImportRow) error {
+			return want
+		})
+
+		assertValue(t, err, want)
+		assertValue(t, summary, ImportSummary{Accepted: 1})
+	})
+}
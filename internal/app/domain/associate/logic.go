@@ -0,0 +1,139 @@
+package associate
+
+import (
+	"errors"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+const (
+	maxIDLength   = 50
+	maxNameLength = 200
+	defaultWeight = 1
+)
+
+var (
+	// ErrAssociateNotFound represents an error caused by an unknown associate
+	ErrAssociateNotFound = errors.New("Associate not found")
+	// ErrDuplicateAssociate represents an error caused by an ID or document already registered
+	ErrDuplicateAssociate = errors.New("Associate ID or document already registered")
+	// ErrBadAssociate represents an error caused by an associate with an invalid ID, name or weight
+	ErrBadAssociate = errors.New("Associate IDs must be non empty and up to 50 characters, names up to 200 characters and weights positive")
+)
+
+// NewAssociateService creates and returns an associate service
+func NewAssociateService(r Repository) Service {
+	return &associateService{
+		repo:  r,
+		clock: clock.System{},
+	}
+}
+
+type associateService struct {
+	repo  Repository
+	clock clock.Clock
+}
+
+// CreateAssociate registers a new active associate
+func (s *associateService) CreateAssociate(id string, p Params) (Associate, error) {
+	a, err := s.build(id, p)
+	if err != nil {
+		return Associate{}, err
+	}
+	if a.Weight == 0 {
+		a.Weight = defaultWeight
+	}
+
+	if err := s.repo.InsertAssociate(a); err != nil {
+		return Associate{}, err
+	}
+	return a, nil
+}
+
+// FindAssociate returns an associate finding by ID
+func (s *associateService) FindAssociate(id string) (Associate, error) {
+	return s.repo.FindAssociate(id)
+}
+
+// UpdateAssociate changes the document, name and weight of an associate,
+// the weight is kept when not informed
+func (s *associateService) UpdateAssociate(id string, p Params) (Associate, error) {
+	a, err := s.repo.FindAssociate(id)
+	if err != nil {
+		return Associate{}, err
+	}
+
+	updated, err := s.build(id, p)
+	if err != nil {
+		return Associate{}, err
+	}
+	a.Document, a.Name, a.Update = updated.Document, updated.Name, updated.Update
+	if updated.Weight != 0 {
+		a.Weight = updated.Weight
+	}
+
+	if err := s.repo.UpdateAssociate(a); err != nil {
+		return Associate{}, err
+	}
+	return a, nil
+}
+
+// DeactivateAssociate keeps the associate registered but unable to vote
+func (s *associateService) DeactivateAssociate(id string) (Associate, error) {
+	a, err := s.repo.FindAssociate(id)
+	if err != nil {
+		return Associate{}, err
+	}
+
+	a.Active = false
+	a.Update = s.clock.Now()
+	if err := s.repo.UpdateAssociate(a); err != nil {
+		return Associate{}, err
+	}
+	return a, nil
+}
+
+// ImportAssociate creates or replaces an associate, imported associates
+// are always active. Replaced associates keep their weight when the import
+// does not inform it
+func (s *associateService) ImportAssociate(id string, p Params) (Associate, error) {
+	a, err := s.build(id, p)
+	if err != nil {
+		return Associate{}, err
+	}
+	return s.repo.UpsertAssociate(a)
+}
+
+// IsEligible reports whether the document belongs to an active associate
+func (s *associateService) IsEligible(document string) (bool, error) {
+	a, err := s.repo.FindAssociateByDocument(vote.NormalizeDocument(document))
+	if errors.Is(err, ErrAssociateNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return a.Active, nil
+}
+
+func (s *associateService) build(id string, p Params) (Associate, error) {
+	if id == "" || len(id) > maxIDLength || len(p.Name) > maxNameLength || p.Weight < 0 {
+		return Associate{}, ErrBadAssociate
+	}
+	document := vote.NormalizeDocument(p.Document)
+	if !vote.ValidDocument(document) {
+		return Associate{}, vote.ErrInvalidDocument
+	}
+
+	now := s.clock.Now()
+	return Associate{
+		ID:       id,
+		Document: document,
+		Name:     p.Name,
+		Weight:   p.Weight,
+		Active:   true,
+		Creation: now,
+		Update:   now,
+	}, nil
+}
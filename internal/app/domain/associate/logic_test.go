@@ -0,0 +1,277 @@
+package associate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+type ClockStub struct {
+	RightNow time.Time
+}
+
+func (c ClockStub) Now() time.Time {
+	return c.RightNow
+}
+
+type AssociateRepoStub struct {
+	store   map[string]Associate
+	rosters map[string]Roster
+}
+
+func (r *AssociateRepoStub) FindAssociate(id string) (Associate, error) {
+	a, ok := r.store[id]
+	if !ok {
+		return Associate{}, ErrAssociateNotFound
+	}
+	return a, nil
+}
+
+func (r *AssociateRepoStub) FindAssociateByDocument(document string) (Associate, error) {
+	if document == "52998224725" && r.store == nil {
+		return Associate{}, errors.New("ops, there was an error")
+	}
+	for _, a := range r.store {
+		if a.Document == document {
+			return a, nil
+		}
+	}
+	return Associate{}, ErrAssociateNotFound
+}
+
+func (r *AssociateRepoStub) InsertAssociate(a Associate) error {
+	if a.ID == "error" {
+		return errors.New("ops, there was an error")
+	}
+	if _, ok := r.store[a.ID]; ok {
+		return ErrDuplicateAssociate
+	}
+	r.store[a.ID] = a
+	return nil
+}
+
+func (r *AssociateRepoStub) UpdateAssociate(a Associate) error {
+	r.store[a.ID] = a
+	return nil
+}
+
+func (r *AssociateRepoStub) UpsertAssociate(a Associate) (Associate, error) {
+	if a.ID == "error" {
+		return Associate{}, errors.New("ops, there was an error")
+	}
+	if a.ID == "wrapped" {
+		return Associate{}, fmt.Errorf("%w: pq: duplicate key value violates unique constraint", ErrDuplicateAssociate)
+	}
+	for _, other := range r.store {
+		if other.Document == a.Document && other.ID != a.ID {
+			return Associate{}, ErrDuplicateAssociate
+		}
+	}
+	if existing, ok := r.store[a.ID]; ok {
+		a.Creation = existing.Creation
+		if a.Weight == 0 {
+			a.Weight = existing.Weight
+		}
+	}
+	if a.Weight == 0 {
+		a.Weight = 1
+	}
+	r.store[a.ID] = a
+	return a, nil
+}
+
+func (r *AssociateRepoStub) InsertRoster(roster Roster) error {
+	if roster.Name == "error" {
+		return errors.New("ops, there was an error")
+	}
+	r.rosters[roster.ID] = roster
+	return nil
+}
+
+func (r *AssociateRepoStub) FindRoster(id string) (Roster, error) {
+	roster, ok := r.rosters[id]
+	if !ok {
+		return Roster{}, ErrRosterNotFound
+	}
+	return roster, nil
+}
+
+const (
+	validCPF  = "52998224725"
+	validCNPJ = "11222333000181"
+)
+
+func TestCreateAssociate(t *testing.T) {
+	now := time.Now()
+	repo := AssociateRepoStub{store: map[string]Associate{}}
+	service := associateService{&repo, &ClockStub{RightNow: now}}
+	t.Run("Returns an active associate", func(t *testing.T) {
+		got, err := service.CreateAssociate("anID", Params{Document: validCPF, Name: "A name"})
+		want := Associate{
+			ID:       "anID",
+			Document: validCPF,
+			Name:     "A name",
+			Weight:   1,
+			Active:   true,
+			Creation: now,
+			Update:   now,
+		}
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	})
+	t.Run("Stores the document without punctuation", func(t *testing.T) {
+		got, _ := service.CreateAssociate("formattedID", Params{Document: "11.222.333/0001-81"})
+
+		assertValue(t, got.Document, validCNPJ)
+		assertValue(t, repo.store["formattedID"].Document, validCNPJ)
+	})
+	t.Run("Returns an invalid document error if the document is malformed", func(t *testing.T) {
+		_, err := service.CreateAssociate("otherID", Params{Document: "12345678900"})
+
+		assertValue(t, err, vote.ErrInvalidDocument)
+	})
+	t.Run("Keeps the informed weight", func(t *testing.T) {
+		got, _ := service.CreateAssociate("weightedID", Params{Document: "39053344705", Weight: 12})
+
+		assertValue(t, got.Weight, 12)
+	})
+	t.Run("Returns a bad associate error if the weight is negative", func(t *testing.T) {
+		_, err := service.CreateAssociate("negativeID", Params{Document: validCPF, Weight: -1})
+
+		assertValue(t, err, ErrBadAssociate)
+	})
+	t.Run("Returns a bad associate error if the ID is empty", func(t *testing.T) {
+		_, err := service.CreateAssociate("", Params{Document: validCPF})
+
+		assertValue(t, err, ErrBadAssociate)
+	})
+	t.Run("Returns a duplicate associate error if the ID is taken", func(t *testing.T) {
+		_, err := service.CreateAssociate("anID", Params{Document: validCPF})
+
+		assertValue(t, err, ErrDuplicateAssociate)
+	})
+}
+
+func TestUpdateAssociate(t *testing.T) {
+	creation := time.Now().Add(-time.Hour)
+	now := time.Now()
+	repo := AssociateRepoStub{store: map[string]Associate{
+		"anID": {ID: "anID", Document: validCPF, Weight: 5, Active: true, Creation: creation},
+	}}
+	service := associateService{&repo, &ClockStub{RightNow: now}}
+	t.Run("Changes the document and name keeping the creation", func(t *testing.T) {
+		got, err := service.UpdateAssociate("anID", Params{Document: validCNPJ, Name: "New name"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Document, validCNPJ)
+		assertValue(t, got.Name, "New name")
+		assertValue(t, got.Creation, creation)
+		assertValue(t, got.Update, now)
+		assertValue(t, repo.store["anID"].Name, "New name")
+	})
+	t.Run("Keeps the weight unless informed", func(t *testing.T) {
+		got, _ := service.UpdateAssociate("anID", Params{Document: validCNPJ})
+		assertValue(t, got.Weight, 5)
+
+		got, _ = service.UpdateAssociate("anID", Params{Document: validCNPJ, Weight: 8})
+		assertValue(t, got.Weight, 8)
+	})
+	t.Run("Returns an associate not found error if it does not exist", func(t *testing.T) {
+		_, err := service.UpdateAssociate("notFound", Params{Document: validCPF})
+
+		assertValue(t, err, ErrAssociateNotFound)
+	})
+}
+
+func TestDeactivateAssociate(t *testing.T) {
+	repo := AssociateRepoStub{store: map[string]Associate{
+		"anID": {ID: "anID", Document: validCPF, Active: true},
+	}}
+	service := associateService{&repo, &ClockStub{RightNow: time.Now()}}
+	t.Run("Keeps the associate registered but inactive", func(t *testing.T) {
+		got, err := service.DeactivateAssociate("anID")
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Active, false)
+		assertValue(t, repo.store["anID"].Active, false)
+	})
+	t.Run("Returns an associate not found error if it does not exist", func(t *testing.T) {
+		_, err := service.DeactivateAssociate("notFound")
+
+		assertValue(t, err, ErrAssociateNotFound)
+	})
+}
+
+func TestImportAssociate(t *testing.T) {
+	creation := time.Now().Add(-time.Hour)
+	repo := AssociateRepoStub{store: map[string]Associate{
+		"anID": {ID: "anID", Document: validCPF, Weight: 5, Active: false, Creation: creation},
+	}}
+	service := associateService{&repo, &ClockStub{RightNow: time.Now()}}
+	t.Run("Reactivates an existing associate", func(t *testing.T) {
+		got, err := service.ImportAssociate("anID", Params{Document: validCPF, Name: "A name"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Active, true)
+		assertValue(t, got.Creation, creation)
+		assertValue(t, got.Weight, 5)
+	})
+	t.Run("Creates a new associate", func(t *testing.T) {
+		_, err := service.ImportAssociate("newID", Params{Document: validCNPJ})
+
+		assertValue(t, err, nil)
+		assertValue(t, repo.store["newID"].Active, true)
+		assertValue(t, repo.store["newID"].Weight, 1)
+	})
+	t.Run("Returns an invalid document error if the document is malformed", func(t *testing.T) {
+		_, err := service.ImportAssociate("newID", Params{Document: "not a document"})
+
+		assertValue(t, err, vote.ErrInvalidDocument)
+	})
+}
+
+func TestIsEligible(t *testing.T) {
+	repo := AssociateRepoStub{store: map[string]Associate{
+		"active":   {ID: "active", Document: validCPF, Active: true},
+		"inactive": {ID: "inactive", Document: validCNPJ, Active: false},
+	}}
+	service := associateService{&repo, &ClockStub{RightNow: time.Now()}}
+	t.Run("Active associates are eligible", func(t *testing.T) {
+		got, err := service.IsEligible("529.982.247-25")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, true)
+	})
+	t.Run("Inactive associates are not eligible", func(t *testing.T) {
+		got, _ := service.IsEligible(validCNPJ)
+
+		assertValue(t, got, false)
+	})
+	t.Run("Unknown documents are not eligible", func(t *testing.T) {
+		got, err := service.IsEligible("11144477735")
+
+		assertValue(t, err, nil)
+		assertValue(t, got, false)
+	})
+	t.Run("Returns the error if there was any error", func(t *testing.T) {
+		failing := associateService{&AssociateRepoStub{}, &ClockStub{}}
+
+		_, err := failing.IsEligible(validCPF)
+
+		assertValue(t, err.Error(), "ops, there was an error")
+	})
+}
+
+func assertValue(t *testing.T, got, want interface{}) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+package associate
+
+import "time"
+
+// Associate Representation of a cooperative associate, only active
+// associates are able to vote. The weight is the voting power of the
+// associate, proportional to its quota holdings
+type Associate struct {
+	ID       string
+	Document string
+	Name     string
+	Weight   int
+	Active   bool
+	Creation time.Time
+	Update   time.Time
+}
+
+// Params Set of parameters describing an associate, a zero weight is not
+// informed: new associates get a weight of one and existing ones keep theirs
+type Params struct {
+	Document string
+	Name     string
+	Weight   int
+}
@@ -0,0 +1,12 @@
+package associate
+
+// Repository Persistency interface to serve the Associate service
+type Repository interface {
+	FindAssociate(string) (Associate, error)
+	FindAssociateByDocument(string) (Associate, error)
+	InsertAssociate(Associate) error
+	UpdateAssociate(Associate) error
+	UpsertAssociate(Associate) (Associate, error)
+	InsertRoster(Roster) error
+	FindRoster(string) (Roster, error)
+}
@@ -0,0 +1,145 @@
+package associate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRosterNotFound represents an error caused by an unknown roster
+	ErrRosterNotFound = errors.New("Roster not found")
+	// ErrBadRoster represents an error caused by a roster without members,
+	// with a name too long or with a negative member weight
+	ErrBadRoster = errors.New("Rosters must have at least one member, names up to 200 characters and positive member weights")
+	// ErrUnknownMember represents an error caused by a roster member that is
+	// neither a registered associate nor a valid document
+	ErrUnknownMember = errors.New("Roster members must be registered associates or have a valid CPF or CNPJ")
+	// ErrMismatchedMember represents an error caused by a roster member
+	// whose associate ID is not registered with the informed document
+	ErrMismatchedMember = errors.New("Roster members informed by associate ID and document must match a registered associate")
+)
+
+// Roster Representation of a closed list of the associates eligible to
+// vote in the sessions referencing it. Rosters are immutable so a session
+// keeps the eligibility it was opened with
+type Roster struct {
+	ID       string
+	Name     string
+	Members  []Member
+	Size     int
+	Creation time.Time
+}
+
+// Member Representation of an associate listed in a roster, members are
+// matched by document. The weight is the member voting power in the
+// sessions restricted to the roster
+type Member struct {
+	AssociateID string
+	Document    string
+	Weight      int
+}
+
+// RosterParams Set of parameters describing a roster, members may be
+// informed by associate ID, by document or by both, in which case both
+// must belong to the same associate. Members without a weight get the
+// weight of their registered associate, or one
+type RosterParams struct {
+	Name    string
+	Members []Member
+}
+
+// CreateRoster stores a new roster, members informed only by associate ID
+// are resolved through the registry and repeated documents are listed once.
+// Weights are copied into the roster, later changes to the associates do
+// not affect it
+func (s *associateService) CreateRoster(p RosterParams) (Roster, error) {
+	if len(p.Members) == 0 || len(p.Name) > maxNameLength {
+		return Roster{}, ErrBadRoster
+	}
+
+	members := make([]Member, 0, len(p.Members))
+	listed := map[string]bool{}
+	for _, m := range p.Members {
+		member, err := s.resolveMember(m)
+		if err != nil {
+			return Roster{}, err
+		}
+		if listed[member.Document] {
+			continue
+		}
+		listed[member.Document] = true
+		members = append(members, member)
+	}
+
+	r := Roster{
+		ID:       uuid.New().String(),
+		Name:     p.Name,
+		Members:  members,
+		Size:     len(members),
+		Creation: s.clock.Now(),
+	}
+	if err := s.repo.InsertRoster(r); err != nil {
+		return Roster{}, err
+	}
+	return r, nil
+}
+
+// FindRoster returns a roster and its members finding by ID
+func (s *associateService) FindRoster(id string) (Roster, error) {
+	return s.repo.FindRoster(id)
+}
+
+func (s *associateService) resolveMember(m Member) (Member, error) {
+	if m.Weight < 0 {
+		return Member{}, ErrBadRoster
+	}
+
+	if m.Document == "" {
+		if m.AssociateID == "" {
+			return Member{}, ErrUnknownMember
+		}
+		a, err := s.repo.FindAssociate(m.AssociateID)
+		if errors.Is(err, ErrAssociateNotFound) {
+			return Member{}, ErrUnknownMember
+		}
+		if err != nil {
+			return Member{}, err
+		}
+		return Member{AssociateID: a.ID, Document: a.Document, Weight: memberWeight(m, a)}, nil
+	}
+
+	document := vote.NormalizeDocument(m.Document)
+	if !vote.ValidDocument(document) {
+		return Member{}, ErrUnknownMember
+	}
+	a, err := s.repo.FindAssociateByDocument(document)
+	if errors.Is(err, ErrAssociateNotFound) {
+		if m.AssociateID != "" {
+			return Member{}, ErrMismatchedMember
+		}
+		return Member{Document: document, Weight: memberWeight(m, Associate{})}, nil
+	}
+	if err != nil {
+		return Member{}, err
+	}
+	if m.AssociateID != "" && m.AssociateID != a.ID {
+		return Member{}, ErrMismatchedMember
+	}
+	return Member{AssociateID: a.ID, Document: document, Weight: memberWeight(m, a)}, nil
+}
+
+// memberWeight returns the weight informed for the member, falling back to
+// the weight of its associate
+func memberWeight(m Member, a Associate) int {
+	switch {
+	case m.Weight > 0:
+		return m.Weight
+	case a.Weight > 0:
+		return a.Weight
+	default:
+		return defaultWeight
+	}
+}
@@ -0,0 +1,96 @@
+package associate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCreateRoster(t *testing.T) {
+	now := time.Now()
+	repo := AssociateRepoStub{
+		store: map[string]Associate{
+			"registered": {ID: "registered", Document: validCNPJ, Weight: 5, Active: true},
+		},
+		rosters: map[string]Roster{},
+	}
+	service := associateService{&repo, &ClockStub{RightNow: now}}
+	t.Run("Stores the roster with its members", func(t *testing.T) {
+		got, err := service.CreateRoster(RosterParams{
+			Name: "Council",
+			Members: []Member{
+				{Document: "529.982.247-25"},
+				{AssociateID: "registered"},
+			},
+		})
+		want := []Member{
+			{Document: validCPF, Weight: 1},
+			{AssociateID: "registered", Document: validCNPJ, Weight: 5},
+		}
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Size, 2)
+		assertValue(t, got.Creation, now)
+		if !reflect.DeepEqual(got.Members, want) {
+			t.Errorf("got %v want %v", got.Members, want)
+		}
+		if _, ok := repo.rosters[got.ID]; !ok {
+			t.Errorf("roster %v was not stored", got.ID)
+		}
+	})
+	t.Run("Resolves members informed by document through the registry", func(t *testing.T) {
+		got, err := service.CreateRoster(RosterParams{
+			Members: []Member{{Document: validCNPJ}, {AssociateID: "registered", Document: validCNPJ, Weight: 2}},
+		})
+		want := []Member{{AssociateID: "registered", Document: validCNPJ, Weight: 5}}
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(got.Members, want) {
+			t.Errorf("got %v want %v", got.Members, want)
+		}
+	})
+	t.Run("Lists repeated documents once", func(t *testing.T) {
+		got, _ := service.CreateRoster(RosterParams{
+			Members: []Member{{Document: validCNPJ}, {AssociateID: "registered"}},
+		})
+
+		assertValue(t, got.Size, 1)
+	})
+	t.Run("Keeps the informed member weights", func(t *testing.T) {
+		got, _ := service.CreateRoster(RosterParams{
+			Members: []Member{{Document: validCPF, Weight: 3}, {AssociateID: "registered", Weight: 2}},
+		})
+
+		assertValue(t, got.Members[0].Weight, 3)
+		assertValue(t, got.Members[1].Weight, 2)
+	})
+	t.Run("Returns a bad roster error if a weight is negative", func(t *testing.T) {
+		_, err := service.CreateRoster(RosterParams{Members: []Member{{Document: validCPF, Weight: -1}}})
+
+		assertValue(t, err, ErrBadRoster)
+	})
+	t.Run("Returns a bad roster error if there are no members", func(t *testing.T) {
+		_, err := service.CreateRoster(RosterParams{Name: "Empty"})
+
+		assertValue(t, err, ErrBadRoster)
+	})
+	t.Run("Returns an unknown member error for unregistered IDs and invalid documents", func(t *testing.T) {
+		for _, m := range []Member{{AssociateID: "unknown"}, {Document: "12345678900"}, {}} {
+			_, err := service.CreateRoster(RosterParams{Members: []Member{m}})
+
+			assertValue(t, err, ErrUnknownMember)
+		}
+	})
+	t.Run("Returns a mismatched member error if the ID does not match the document", func(t *testing.T) {
+		for _, m := range []Member{{AssociateID: "other", Document: validCNPJ}, {AssociateID: "registered", Document: validCPF}} {
+			_, err := service.CreateRoster(RosterParams{Members: []Member{m}})
+
+			assertValue(t, err, ErrMismatchedMember)
+		}
+	})
+	t.Run("Returns the error if there was any error", func(t *testing.T) {
+		_, err := service.CreateRoster(RosterParams{Name: "error", Members: []Member{{Document: validCPF}}})
+
+		assertValue(t, err.Error(), "ops, there was an error")
+	})
+}
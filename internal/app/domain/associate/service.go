@@ -0,0 +1,13 @@
+package associate
+
+// Service describes the associate service interface
+type Service interface {
+	CreateAssociate(string, Params) (Associate, error)
+	FindAssociate(string) (Associate, error)
+	UpdateAssociate(string, Params) (Associate, error)
+	DeactivateAssociate(string) (Associate, error)
+	ImportAssociate(string, Params) (Associate, error)
+	IsEligible(string) (bool, error)
+	CreateRoster(RosterParams) (Roster, error)
+	FindRoster(string) (Roster, error)
+}
@@ -0,0 +1,17 @@
+package clock
+
+import "time"
+
+// Clock describes the source of the current time of the services, so
+// tests can stop it
+type Clock interface {
+	Now() time.Time
+}
+
+// System Clock reading the system time, shared by the services
+type System struct{}
+
+// Now Returns the current system time
+func (System) Now() time.Time {
+	return time.Now()
+}
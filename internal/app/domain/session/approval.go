@@ -0,0 +1,91 @@
+package session
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
+// ErrBadApproval represents an error caused by an approval session with
+// more seats or selections than agenda options
+var ErrBadApproval = errors.New("Approval sessions must have positive seats and maximum selections up to the number of agenda options")
+
+// approvals counts, for every option, the ballots approving it. Options
+// are kept in the order they were declared by the agenda
+func approvals(options []agenda.Option, ballots []Ballot) []OptionCount {
+	counts := make([]OptionCount, len(options))
+	position := map[string]int{}
+	for i, o := range options {
+		counts[i].OptionID = o.ID
+		position[o.ID] = i
+	}
+
+	for _, b := range ballots {
+		if b.Vote == agenda.Abstention {
+			continue
+		}
+		for _, id := range b.Selections {
+			i, ok := position[id]
+			if !ok {
+				continue
+			}
+			counts[i].Votes++
+			counts[i].Weight += b.Weight
+			if b.Delegated {
+				counts[i].Delegated++
+			}
+		}
+	}
+	return counts
+}
+
+// approvalCount counts the approvals of every option, the ballots are
+// counted once in Approving for the turnout and the quorum
+func approvalCount(options []agenda.Option, ballots []Ballot) Count {
+	c := count(options, ballots)
+	approving := OptionCount{}
+	for _, o := range c.Options {
+		approving.Votes += o.Votes
+		approving.Weight += o.Weight
+		approving.Delegated += o.Delegated
+	}
+	c.Options, c.Approving = approvals(options, ballots), &approving
+	return c
+}
+
+// appoint elects the most approved options, on the rule basis, up to the
+// number of seats. Options without approvals are not elected. The session
+// is tied when the last seat is disputed by options with the same
+// approvals, only the options ahead of them are elected then
+func (r *DecisionRule) appoint(c Count, counts []OptionCount, seats int) (outcome string, winners []string) {
+	if !r.hasQuorum(c) {
+		return OutcomeNoQuorum, nil
+	}
+
+	ranked := make([]OptionCount, len(counts))
+	copy(ranked, counts)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return r.votes(ranked[i]) > r.votes(ranked[j])
+	})
+
+	for i := 0; i < seats && i < len(ranked); i++ {
+		votes := r.votes(ranked[i])
+		if votes == 0 {
+			break
+		}
+		if i == seats-1 && i+1 < len(ranked) && r.votes(ranked[i+1]) == votes {
+			cut := len(winners)
+			for cut > 0 && r.votes(ranked[cut-1]) == votes {
+				cut--
+			}
+			return OutcomeTied, winners[:cut]
+		}
+		winners = append(winners, ranked[i].OptionID)
+	}
+
+	if len(winners) == 0 {
+		return OutcomeRejected, nil
+	}
+	return OutcomeElected, winners
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
+func TestApprovals(t *testing.T) {
+	options := []agenda.Option{{ID: "A"}, {ID: "B"}, {ID: "C"}}
+	got := approvals(options, []Ballot{
+		{Vote: "A", Weight: 1, Selections: []string{"A", "B"}},
+		{Vote: "B", Weight: 3, Delegated: true, Selections: []string{"B"}},
+		{Vote: agenda.Abstention, Weight: 1},
+	})
+	want := []OptionCount{{"A", 1, 1, 0}, {"B", 2, 4, 1}, {"C", 0, 0, 0}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestAppoint(t *testing.T) {
+	counts := func(votes ...int) []OptionCount {
+		ids := []string{"A", "B", "C", "D"}
+		c := make([]OptionCount, len(votes))
+		for i, v := range votes {
+			c[i] = OptionCount{OptionID: ids[i], Votes: v, Weight: v}
+		}
+		return c
+	}
+	turnout := Count{Options: []OptionCount{{"A", 5, 5, 0}}}
+	cases := []struct {
+		name        string
+		rule        DecisionRule
+		counts      []OptionCount
+		seats       int
+		wantOutcome string
+		wantWinners []string
+	}{
+		{"elects the most approved option", DecisionRule{}, counts(2, 4, 1), 1, OutcomeElected, []string{"B"}},
+		{"elects the top options by approvals", DecisionRule{}, counts(2, 4, 3, 1), 2, OutcomeElected, []string{"B", "C"}},
+		{"ties on the last seat", DecisionRule{}, counts(4, 2, 2), 2, OutcomeTied, []string{"A"}},
+		{"ties every seat", DecisionRule{}, counts(2, 2, 2), 2, OutcomeTied, nil},
+		{"does not elect options without approvals", DecisionRule{}, counts(3, 0, 0), 2, OutcomeElected, []string{"A"}},
+		{"rejects without approvals", DecisionRule{}, counts(0, 0), 1, OutcomeRejected, nil},
+		{"requires the quorum", DecisionRule{QuorumVoters: 10}, counts(3, 1), 1, OutcomeNoQuorum, nil},
+		{"weighs the approvals on the rule basis", DecisionRule{Basis: WeightedBasis}, []OptionCount{{"A", 3, 3, 0}, {"B", 1, 9, 0}}, 1, OutcomeElected, []string{"B"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			outcome, winners := c.rule.appoint(turnout, c.counts, c.seats)
+
+			assertValue(t, outcome, c.wantOutcome)
+			if len(winners) != len(c.wantWinners) || len(winners) > 0 && !reflect.DeepEqual(winners, c.wantWinners) {
+				t.Errorf("got %v want %v", winners, c.wantWinners)
+			}
+		})
+	}
+}
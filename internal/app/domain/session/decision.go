@@ -0,0 +1,135 @@
+package session
+
+import (
+	"errors"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
+// Majorities a decision rule may require from the leading option
+const (
+	SimpleMajority    = "simple"
+	AbsoluteMajority  = "absolute"
+	TwoThirdsMajority = "two-thirds"
+)
+
+// Bases a decision rule may weigh the votes on, by headcount every vote
+// counts as one, weighted every vote counts as the weight of its voter
+const (
+	HeadcountBasis = "headcount"
+	WeightedBasis  = "weighted"
+)
+
+// Outcomes of a voting session
+const (
+	OutcomePending  = "pending"
+	OutcomeApproved = "approved"
+	OutcomeRejected = "rejected"
+	OutcomeTied     = "tied"
+	OutcomeNoQuorum = "no-quorum"
+	OutcomeElected  = "elected"
+	OutcomeVoid     = "void"
+)
+
+// ErrBadDecisionRule represents an error caused by an invalid decision rule
+var ErrBadDecisionRule = errors.New("Invalid decision rule. Majority must be 'simple', 'absolute' or 'two-thirds', basis 'headcount' or 'weighted' and quorum must be positive, quorum percent requires the number of eligible voters")
+
+// DecisionRule Representation of the rule used to decide a session
+// outcome. The agenda first option is the proposal under vote, the
+// session is approved when it is the option winning under the rule.
+// The basis weighs the majority, the quorum is always by headcount
+type DecisionRule struct {
+	Majority       string
+	Basis          string
+	QuorumVoters   int
+	QuorumPercent  int
+	EligibleVoters int
+}
+
+func (r *DecisionRule) validate() error {
+	switch r.Majority {
+	case SimpleMajority, AbsoluteMajority, TwoThirdsMajority:
+	default:
+		return ErrBadDecisionRule
+	}
+	switch r.Basis {
+	case HeadcountBasis, WeightedBasis:
+	default:
+		return ErrBadDecisionRule
+	}
+	if r.QuorumVoters < 0 || r.EligibleVoters < 0 {
+		return ErrBadDecisionRule
+	}
+	if r.QuorumPercent < 0 || r.QuorumPercent > 100 {
+		return ErrBadDecisionRule
+	}
+	if r.QuorumPercent > 0 && r.EligibleVoters == 0 {
+		return ErrBadDecisionRule
+	}
+	return nil
+}
+
+// hasQuorum abstentions count toward the quorum
+func (r *DecisionRule) hasQuorum(c Count) bool {
+	turnout := c.Turnout()
+	if turnout < r.QuorumVoters {
+		return false
+	}
+	return turnout*100 >= r.QuorumPercent*r.EligibleVoters
+}
+
+// reaches abstentions are excluded from the majority calculation
+func (r *DecisionRule) reaches(votes, valid int) bool {
+	switch r.Majority {
+	case AbsoluteMajority:
+		return votes*2 > valid
+	case TwoThirdsMajority:
+		return votes*3 >= valid*2
+	default:
+		return votes > 0
+	}
+}
+
+// votes returns the votes of an option on the rule basis
+func (r *DecisionRule) votes(o OptionCount) int {
+	if r.Basis == WeightedBasis {
+		return o.Weight
+	}
+	return o.Votes
+}
+
+// valid returns the valid votes of the count on the rule basis
+func (r *DecisionRule) valid(c Count) int {
+	if r.Basis == WeightedBasis {
+		return c.WeightedValid()
+	}
+	return c.Valid()
+}
+
+func (r *DecisionRule) decide(options []agenda.Option, c Count) (outcome string, winner string) {
+	if !r.hasQuorum(c) {
+		return OutcomeNoQuorum, ""
+	}
+
+	leading, leadingVotes, tied := OptionCount{}, 0, false
+	for _, o := range c.Options {
+		votes := r.votes(o)
+		switch {
+		case votes > leadingVotes:
+			leading, leadingVotes, tied = o, votes, false
+		case votes == leadingVotes && votes > 0:
+			tied = true
+		}
+	}
+	if tied {
+		return OutcomeTied, ""
+	}
+	if !r.reaches(leadingVotes, r.valid(c)) {
+		return OutcomeRejected, ""
+	}
+
+	if len(options) > 0 && leading.OptionID == options[0].ID {
+		return OutcomeApproved, leading.OptionID
+	}
+	return OutcomeRejected, leading.OptionID
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
+func TestDecide(t *testing.T) {
+	options := agenda.DefaultOptions
+	count := func(inFavor, against, abstentions int) Count {
+		return Count{
+			Options:     []OptionCount{{"S", inFavor, inFavor, 0}, {"N", against, against, 0}},
+			Abstentions: abstentions,
+		}
+	}
+	weighted := func(inFavor, inFavorWeight, against, againstWeight int) Count {
+		return Count{Options: []OptionCount{{"S", inFavor, inFavorWeight, 0}, {"N", against, againstWeight, 0}}}
+	}
+	cases := []struct {
+		name        string
+		rule        DecisionRule
+		count       Count
+		wantOutcome string
+		wantWinner  string
+	}{
+		{"simple majority approves", DecisionRule{Majority: SimpleMajority}, count(3, 2, 0), OutcomeApproved, "S"},
+		{"simple majority rejects", DecisionRule{Majority: SimpleMajority}, count(2, 3, 0), OutcomeRejected, "N"},
+		{"simple majority ties", DecisionRule{Majority: SimpleMajority}, count(3, 3, 0), OutcomeTied, ""},
+		{"no votes rejects", DecisionRule{Majority: SimpleMajority}, count(0, 0, 0), OutcomeRejected, ""},
+		{"abstentions are excluded from the majority", DecisionRule{Majority: AbsoluteMajority}, count(3, 2, 10), OutcomeApproved, "S"},
+		{"absolute majority requires more than half", DecisionRule{Majority: AbsoluteMajority}, count(2, 2, 0), OutcomeTied, ""},
+		{"two-thirds approves", DecisionRule{Majority: TwoThirdsMajority}, count(4, 2, 0), OutcomeApproved, "S"},
+		{"two-thirds rejects", DecisionRule{Majority: TwoThirdsMajority}, count(3, 2, 0), OutcomeRejected, ""},
+		{"quorum of voters not reached", DecisionRule{Majority: SimpleMajority, QuorumVoters: 10}, count(3, 2, 0), OutcomeNoQuorum, ""},
+		{"abstentions count toward the quorum", DecisionRule{Majority: SimpleMajority, QuorumVoters: 10}, count(3, 2, 5), OutcomeApproved, "S"},
+		{"quorum percent not reached", DecisionRule{Majority: SimpleMajority, QuorumPercent: 50, EligibleVoters: 20}, count(5, 4, 0), OutcomeNoQuorum, ""},
+		{"quorum percent reached", DecisionRule{Majority: SimpleMajority, QuorumPercent: 50, EligibleVoters: 20}, count(5, 4, 1), OutcomeApproved, "S"},
+		{"headcount ignores the weights", DecisionRule{Majority: SimpleMajority, Basis: HeadcountBasis}, weighted(3, 3, 2, 20), OutcomeApproved, "S"},
+		{"weighted majority uses the weights", DecisionRule{Majority: SimpleMajority, Basis: WeightedBasis}, weighted(3, 3, 2, 20), OutcomeRejected, "N"},
+		{"weighted absolute majority", DecisionRule{Majority: AbsoluteMajority, Basis: WeightedBasis}, weighted(1, 11, 4, 10), OutcomeApproved, "S"},
+		{"weighted ties", DecisionRule{Majority: SimpleMajority, Basis: WeightedBasis}, weighted(1, 5, 5, 5), OutcomeTied, ""},
+		{"quorum stays by headcount", DecisionRule{Majority: SimpleMajority, Basis: WeightedBasis, QuorumVoters: 3}, weighted(1, 50, 1, 10), OutcomeNoQuorum, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			outcome, winner := c.rule.decide(options, c.count)
+
+			assertValue(t, outcome, c.wantOutcome)
+			assertValue(t, winner, c.wantWinner)
+		})
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	t.Run("accepts the known majorities", func(t *testing.T) {
+		for _, m := range []string{SimpleMajority, AbsoluteMajority, TwoThirdsMajority} {
+			r := DecisionRule{Majority: m, Basis: HeadcountBasis}
+			assertValue(t, r.validate(), nil)
+		}
+	})
+	t.Run("accepts the known bases", func(t *testing.T) {
+		for _, b := range []string{HeadcountBasis, WeightedBasis} {
+			r := DecisionRule{Majority: SimpleMajority, Basis: b}
+			assertValue(t, r.validate(), nil)
+		}
+	})
+	t.Run("rejects an unknown basis", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority, Basis: "shares"}
+		assertValue(t, r.validate(), ErrBadDecisionRule)
+	})
+	t.Run("rejects a quorum percent without eligible voters", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority, Basis: HeadcountBasis, QuorumPercent: 50}
+		assertValue(t, r.validate(), ErrBadDecisionRule)
+	})
+	t.Run("rejects a quorum percent above 100", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority, Basis: HeadcountBasis, QuorumPercent: 150, EligibleVoters: 10}
+		assertValue(t, r.validate(), ErrBadDecisionRule)
+	})
+}
@@ -1,107 +1,284 @@
 package session
 
 import (
+	"errors"
 	"time"
 
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
 	"github.com/google/uuid"
 )
 
-// NewSessionService creates and returns an agenda service
-func NewSessionService(r Repository, p Publisher) Service {
+var (
+	// ErrSessionNotFound represents an error caused by an unknown session or
+	// by a session that does not belong to the informed agenda
+	ErrSessionNotFound = errors.New("Session not found")
+	// ErrSecretVoteChanges represents an error caused by a secret session
+	// allowing vote changes, its ballots can not be traced back to change them
+	ErrSecretVoteChanges = errors.New("Secret ballot sessions can not allow vote changes")
+	// ErrBadStart represents an error caused by a session scheduled to start
+	// in the past
+	ErrBadStart = errors.New("Sessions must start in the future")
+	// ErrBadMethod represents an error caused by an unknown voting method
+	ErrBadMethod = errors.New("Invalid voting method. Must be 'plurality', 'ranked' or 'approval'")
+	// ErrBadDuration represents an error caused by a session lasting for a
+	// non positive time or out of the duration limits, or informing both
+	// its duration and closing time
+	ErrBadDuration = errors.New("Invalid duration. Must be a positive duration or a closing time after the opening, within the allowed limits")
+)
+
+// DefaultDuration Duration of the sessions created without informing it
+const DefaultDuration = time.Minute
+
+// NewSessionService creates and returns an agenda service, the limits
+// bound the administrative changes to the sessions
+func NewSessionService(r Repository, l Limits) Service {
 	return &sessionService{
-		repo:  r,
-		pub:   p,
-		clock: &internalClock{},
+		repo:   r,
+		clock:  clock.System{},
+		limits: l,
 	}
 }
 
 type sessionService struct {
-	repo  Repository
-	pub   Publisher
-	clock clock
+	repo   Repository
+	clock  clock.Clock
+	limits Limits
 }
 
-type internalClock struct{}
+// CreateSession creates an session em stores it, sessions restricted to a
+// roster have as many eligible voters as the roster members. Sessions with
+// a start stay scheduled until then
+func (s *sessionService) CreateSession(agendaID string, p Params) (Session, error) {
+	a, err := s.repo.FindAgenda(agendaID)
+	if err != nil {
+		return Session{}, err
+	}
 
-func (c *internalClock) Now() time.Time {
-	return time.Now()
-}
+	if p.Secret && p.VoteChanges {
+		return Session{}, ErrSecretVoteChanges
+	}
 
-type clock interface {
-	Now() time.Time
-}
+	method := p.Method
+	if method == "" {
+		method = PluralityMethod
+	}
+	switch method {
+	case PluralityMethod, RankedMethod:
+	case ApprovalMethod:
+		if p.Seats == 0 {
+			p.Seats = 1
+		}
+		if p.Seats < 0 || p.Seats > len(a.Options) || p.MaxSelections < 0 || p.MaxSelections > len(a.Options) {
+			return Session{}, ErrBadApproval
+		}
+	default:
+		return Session{}, ErrBadMethod
+	}
 
-// CreateSession creates an session em stores it
-func (s *sessionService) CreateSession(agendaID string, duration time.Duration) (Session, error) {
 	id := uuid.New()
+	now := s.clock.Now()
+
+	start := p.Start
+	if start.IsZero() {
+		start = now
+	}
+	if start.Before(now) {
+		return Session{}, ErrBadStart
+	}
 
-	if duration == 0 {
-		duration = time.Minute
+	duration, err := s.duration(p, start)
+	if err != nil {
+		return Session{}, err
+	}
+
+	rule := p.Rule
+	if rule.Majority == "" {
+		rule.Majority = SimpleMajority
+	}
+	if rule.Basis == "" {
+		rule.Basis = HeadcountBasis
+	}
+	if p.RosterID != "" {
+		size, err := s.repo.FindRosterSize(p.RosterID)
+		if err != nil {
+			return Session{}, err
+		}
+		rule.EligibleVoters = size
+	}
+	if err := rule.validate(); err != nil {
+		return Session{}, err
 	}
 
 	session := Session{
 		ID:             id.String(),
 		OriginalAgenda: agendaID,
 		Duration:       duration,
-		Creation:       s.clock.Now(),
+		Creation:       now,
+		Start:          start,
+		State:          StateScheduled,
+		Rule:           rule,
+		RosterID:       p.RosterID,
+		Secret:         p.Secret,
+		VoteChanges:    p.VoteChanges,
+		Method:         method,
+	}
+	if method == ApprovalMethod {
+		session.MaxSelections, session.Seats = p.MaxSelections, p.Seats
 	}
+	session.State = session.StateAt(now)
 
-	err := s.repo.InsertSession(session)
-	if err != nil {
+	if err := s.repo.InsertSession(session); err != nil {
 		return Session{}, err
 	}
-
-	t := time.NewTimer(session.Duration + (10 * time.Second))
-	go notifyResult(t, session, s)
-
 	return session, nil
 }
 
-func notifyResult(t *time.Timer, session Session, s *sessionService) {
-	<-t.C
-	defer t.Stop()
+// duration returns the informed duration, or the time from the start to
+// the informed closing, as long as it is within the limits. Sessions
+// informing neither last the default duration
+func (s *sessionService) duration(p Params, start time.Time) (time.Duration, error) {
+	var d time.Duration
+	switch {
+	case !p.Closes.IsZero() && p.Duration != nil:
+		return 0, ErrBadDuration
+	case !p.Closes.IsZero():
+		d = p.Closes.Sub(start)
+	case p.Duration != nil:
+		d = *p.Duration
+	default:
+		d = DefaultDuration
+	}
+
+	if d <= 0 || d < s.limits.MinDuration || (s.limits.MaxDuration > 0 && d > s.limits.MaxDuration) {
+		return 0, ErrBadDuration
+	}
+	return d, nil
+}
 
-	result, err := s.Result(session.ID)
+// FindSession returns a session finding by ID along with its current
+// state, the session must belong to the informed agenda
+func (s *sessionService) FindSession(agendaID, id string) (Session, error) {
+	session, err := s.findStored(agendaID, id)
 	if err != nil {
-		return
+		return Session{}, err
 	}
-	err = s.pub.PublishResult(result)
+	session.State = session.StateAt(s.clock.Now())
+	return session, nil
 }
 
-// FindSession returns a session finding by ID
-func (s *sessionService) FindSession(id string) (Session, error) {
+// findStored returns the session as stored, its state is the last one
+// stored and may lag behind the time
+func (s *sessionService) findStored(agendaID, id string) (Session, error) {
 	session, err := s.repo.FindSession(id)
 	if err != nil {
 		return Session{}, err
 	}
+	if session.OriginalAgenda != agendaID {
+		return Session{}, ErrSessionNotFound
+	}
 	return session, nil
 }
 
-// Result returns a voting session result
-func (s *sessionService) Result(id string) (Result, error) {
-	session, err := s.repo.FindSession(id)
+// ListSessions returns the sessions opened for an agenda, oldest first,
+// along with their current state
+func (s *sessionService) ListSessions(agendaID string) ([]Session, error) {
+	if _, err := s.repo.FindAgenda(agendaID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repo.FindSessions(agendaID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	for i := range sessions {
+		sessions[i].State = sessions[i].StateAt(now)
+	}
+	return sessions, nil
+}
+
+// Result returns a voting session result, the result of a cancelled
+// session is void
+func (s *sessionService) Result(agendaID, id string) (Result, error) {
+	session, err := s.FindSession(agendaID, id)
 	if err != nil {
 		return Result{}, err
 	}
 
-	votes, err := s.repo.FindVotes(session)
+	a, err := s.repo.FindAgenda(session.OriginalAgenda)
 	if err != nil {
 		return Result{}, err
 	}
 
-	c := Count{}
-	for _, v := range votes {
-		if v == "S" {
-			c.InFavor++
-		} else {
-			c.Against++
-		}
+	votes, err := s.repo.FindVotes(session)
+	if err != nil {
+		return Result{}, err
 	}
 
-	return Result{
+	result := Result{
 		ID:             session.ID,
 		OriginalAgenda: session.OriginalAgenda,
-		Closed:         s.clock.Now().After(session.GetExpiration()),
-		Count:          c,
-	}, nil
+		Closed:         session.EndedAt(s.clock.Now()),
+		Void:           session.State == StateCancelled,
+		Count:          count(a.Options, votes),
+		Eligible:       session.Rule.EligibleVoters,
+		Outcome:        OutcomePending,
+	}
+	if result.Void {
+		result.Outcome = OutcomeVoid
+		return result, nil
+	}
+	if session.Method == ApprovalMethod {
+		result.Count = approvalCount(a.Options, votes)
+		result.Approvals = result.Count.Options
+		if result.Closed {
+			result.Outcome, result.Winners = session.Rule.appoint(result.Count, result.Approvals, session.Seats)
+			if session.Seats == 1 && len(result.Winners) == 1 {
+				result.Winner = result.Winners[0]
+			}
+		}
+		return result, nil
+	}
+	if session.Method == RankedMethod {
+		rounds, winner := runoff(a.Options, votes, session.Rule)
+		result.Rounds = rounds
+		if result.Closed {
+			result.Outcome, result.Winner = session.Rule.elect(result.Count, winner)
+		}
+		return result, nil
+	}
+	if result.Closed {
+		result.Outcome, result.Winner = session.Rule.decide(a.Options, result.Count)
+	}
+	return result, nil
+}
+
+func count(options []agenda.Option, ballots []Ballot) Count {
+	c := Count{Options: make([]OptionCount, len(options))}
+	position := map[string]int{}
+	for i, o := range options {
+		c.Options[i].OptionID = o.ID
+		position[o.ID] = i
+	}
+
+	for _, b := range ballots {
+		if b.Vote == agenda.Abstention {
+			c.Abstentions++
+			c.AbstentionWeight += b.Weight
+			if b.Delegated {
+				c.DelegatedAbstentions++
+			}
+			continue
+		}
+		if i, ok := position[b.Vote]; ok {
+			c.Options[i].Votes++
+			c.Options[i].Weight += b.Weight
+			if b.Delegated {
+				c.Options[i].Delegated++
+			}
+		}
+	}
+	return c
 }
@@ -3,9 +3,11 @@ package session
 import (
 	"errors"
 	"reflect"
-	"sync"
+	"sort"
 	"testing"
 	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
 )
 
 type ClockStub struct {
@@ -16,18 +18,51 @@ func (c ClockStub) Now() time.Time {
 	return c.RightNow
 }
 
+func lasting(d time.Duration) *time.Duration {
+	return &d
+}
+
 type SessionRepoStub struct {
-	store map[string]Session
+	store       map[string]Session
+	published   map[string]bool
+	events      []Event
+	transitions []Transition
+}
+
+func (r *SessionRepoStub) FindAgenda(ID string) (agenda.Agenda, error) {
+	if ID == "notFound" {
+		return agenda.Agenda{}, agenda.ErrAgendaNotFound
+	}
+	return agenda.Agenda{
+		ID:      ID,
+		Options: agenda.DefaultOptions,
+	}, nil
 }
 
 func (r *SessionRepoStub) FindSession(ID string) (Session, error) {
 	session, ok := r.store[ID]
 	if ok == false {
-		return Session{}, errors.New("Session not found")
+		return Session{}, ErrSessionNotFound
 	}
 	return session, nil
 }
 
+func (r *SessionRepoStub) FindSessions(agendaID string) ([]Session, error) {
+	if agendaID == "error" {
+		return nil, errors.New("ops, there was an error")
+	}
+	sessions := []Session{}
+	for _, s := range r.store {
+		if s.OriginalAgenda == agendaID {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Creation.Before(sessions[j].Creation)
+	})
+	return sessions, nil
+}
+
 func (r *SessionRepoStub) InsertSession(s Session) error {
 	if s.OriginalAgenda == "error" {
 		return errors.New("ops, there was an error")
@@ -36,19 +71,77 @@ func (r *SessionRepoStub) InsertSession(s Session) error {
 	return nil
 }
 
-func (r *SessionRepoStub) FindVotes(s Session) ([]string, error) {
+func (r *SessionRepoStub) FindRosterSize(id string) (int, error) {
+	if id == "notFound" {
+		return 0, errors.New("Roster not found")
+	}
+	return 10, nil
+}
+
+func (r *SessionRepoStub) FindVotes(s Session) ([]Ballot, error) {
 	if s.OriginalAgenda == "error" {
-		return []string{}, errors.New("ops, there was an error")
+		return []Ballot{}, errors.New("ops, there was an error")
+	}
+	if s.Method == ApprovalMethod {
+		return []Ballot{
+			{Vote: "S", Weight: 1, Selections: []string{"S", "N"}},
+			{Vote: "S", Weight: 1, Selections: []string{"S"}},
+			{Vote: agenda.Abstention, Weight: 1},
+		}, nil
 	}
-	return []string{"S", "N", "S", "N", "S"}, nil
+	return []Ballot{
+		{Vote: "S", Weight: 1}, {Vote: "N", Weight: 4, Delegated: true}, {Vote: "S", Weight: 1}, {Vote: "N", Weight: 4}, {Vote: "S", Weight: 1}, {Vote: agenda.Abstention, Weight: 2, Delegated: true},
+	}, nil
+}
+
+func (r *SessionRepoStub) FindUnpublishedSessions(now time.Time) ([]Session, error) {
+	sessions := []Session{}
+	for _, s := range r.store {
+		if !r.published[s.ID] && s.EndedAt(now) {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepoStub) MarkResultPublished(found, s Session, e Event) error {
+	if r.published == nil {
+		r.published = map[string]bool{}
+	}
+	if r.published[s.ID] || r.store[s.ID].Duration != found.Duration {
+		return ErrBadTransition
+	}
+	r.published[s.ID] = true
+	r.store[s.ID] = s
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *SessionRepoStub) InsertTransition(found, s Session, t Transition) error {
+	if s.OriginalAgenda == "error" {
+		return errors.New("ops, there was an error")
+	}
+	if stored := r.store[s.ID]; stored.State != found.State || stored.Duration != found.Duration {
+		return ErrBadTransition
+	}
+	if t.Action == ReopenAction {
+		delete(r.published, s.ID)
+	}
+	r.store[s.ID] = s
+	r.transitions = append(r.transitions, t)
+	return nil
 }
 
 type PublisherStub struct {
 	CalledWith []interface{}
-	wg         sync.WaitGroup
+	Err        error
 }
 
 func (p *PublisherStub) PublishResult(r Result) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.CalledWith = append(p.CalledWith, r)
 	return nil
 }
 
@@ -56,13 +149,12 @@ func TestCreateSession(t *testing.T) {
 	now := time.Now()
 	store := map[string]Session{}
 	clockStub := ClockStub{RightNow: now}
-	pubStub := PublisherStub{CalledWith: []interface{}{}}
-	repo := SessionRepoStub{store}
-	service := sessionService{&repo, &pubStub, &clockStub}
+	repo := SessionRepoStub{store: store}
+	service := sessionService{&repo, &clockStub, Limits{}}
 	t.Run("Returns an session", func(t *testing.T) {
 		agendaID := "anID"
 		duration := time.Duration(time.Minute) * 5
-		got, _ := service.CreateSession(agendaID, duration)
+		got, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 		want := Session{}
 
 		assertType(t, got, want)
@@ -72,16 +164,160 @@ func TestCreateSession(t *testing.T) {
 		assertValue(t, got.Duration, duration)
 		assertValue(t, got.Creation, now)
 	})
-	t.Run("If informed duration is zero should assume 1 minute", func(t *testing.T) {
+	t.Run("If no duration is informed should assume 1 minute", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) * 0
-		got, _ := service.CreateSession(agendaID, duration)
+		got, _ := service.CreateSession(agendaID, Params{})
 		want := time.Minute
 
 		assertValue(t, got.Duration, want)
 	})
+	t.Run("Lasts until the informed closing time", func(t *testing.T) {
+		got, err := service.CreateSession("anID", Params{Closes: now.Add(2 * time.Hour)})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Duration, 2*time.Hour)
+		assertValue(t, got.GetExpiration(), now.Add(2*time.Hour))
+	})
+	t.Run("Lasts from the scheduled start until the closing time", func(t *testing.T) {
+		got, err := service.CreateSession("anID", Params{Start: now.Add(time.Hour), Closes: now.Add(3 * time.Hour)})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Duration, 2*time.Hour)
+	})
+	t.Run("Returns a Bad Duration error for invalid durations", func(t *testing.T) {
+		cases := map[string]Params{
+			"negative duration":    {Duration: lasting(-time.Minute)},
+			"closing in the past":  {Closes: now.Add(-time.Minute)},
+			"closing before start": {Start: now.Add(time.Hour), Closes: now.Add(time.Minute)},
+			"duration and closing": {Duration: lasting(time.Hour), Closes: now.Add(time.Hour)},
+			"zero duration":        {Duration: lasting(0)},
+		}
+		for name, p := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := service.CreateSession("anID", p)
+
+				assertValue(t, err, ErrBadDuration)
+			})
+		}
+	})
+	t.Run("Returns a Bad Duration error out of the duration limits", func(t *testing.T) {
+		limited := sessionService{&repo, &clockStub, Limits{MinDuration: 5 * time.Minute, MaxDuration: 24 * time.Hour}}
+
+		_, err := limited.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+		assertValue(t, err, ErrBadDuration)
+		_, err = limited.CreateSession("anID", Params{Closes: now.Add(48 * time.Hour)})
+		assertValue(t, err, ErrBadDuration)
+		got, err := limited.CreateSession("anID", Params{Duration: lasting(time.Hour)})
+		assertValue(t, err, nil)
+		assertValue(t, got.Duration, time.Hour)
+	})
+	t.Run("Uses a simple majority if no rule was informed", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		assertValue(t, got.Rule.Majority, SimpleMajority)
+		assertValue(t, got.Rule.Basis, HeadcountBasis)
+	})
+	t.Run("Returns a bad decision rule error if the rule is invalid", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Rule: DecisionRule{Majority: "unanimity"}})
+
+		assertValue(t, got, ErrBadDecisionRule)
+	})
+	t.Run("Returns an agenda not found error if the agenda does not exist", func(t *testing.T) {
+		_, got := service.CreateSession("notFound", Params{Duration: lasting(time.Minute)})
+
+		assertValue(t, got, agenda.ErrAgendaNotFound)
+	})
+	t.Run("Takes the eligible voters from the roster", func(t *testing.T) {
+		got, err := service.CreateSession("anID", Params{
+			RosterID: "aRoster",
+			Rule:     DecisionRule{QuorumPercent: 50, EligibleVoters: 1000},
+		})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.RosterID, "aRoster")
+		assertValue(t, got.Rule.EligibleVoters, 10)
+	})
+	t.Run("Keeps the secret ballot mode", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{Secret: true})
+
+		assertValue(t, got.Secret, true)
+		assertValue(t, repo.store[got.ID].Secret, true)
+	})
+	t.Run("Keeps the vote changes policy", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{VoteChanges: true})
+
+		assertValue(t, got.VoteChanges, true)
+	})
+	t.Run("Returns a secret vote changes error if a secret session allows changes", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Secret: true, VoteChanges: true})
+
+		assertValue(t, got, ErrSecretVoteChanges)
+	})
+	t.Run("Uses the plurality method if no method was informed", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{})
+
+		assertValue(t, got.Method, PluralityMethod)
+	})
+	t.Run("Keeps the ranked method", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{Method: RankedMethod})
+
+		assertValue(t, repo.store[got.ID].Method, RankedMethod)
+	})
+	t.Run("Returns a bad method error if the method is unknown", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Method: "borda"})
+
+		assertValue(t, got, ErrBadMethod)
+	})
+	t.Run("Elects one seat from any number of selections by default", func(t *testing.T) {
+		got, err := service.CreateSession("anID", Params{Method: ApprovalMethod})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Seats, 1)
+		assertValue(t, got.MaxSelections, 0)
+	})
+	t.Run("Keeps the seats and the maximum selections", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{Method: ApprovalMethod, Seats: 2, MaxSelections: 1})
+
+		assertValue(t, repo.store[got.ID].Seats, 2)
+		assertValue(t, repo.store[got.ID].MaxSelections, 1)
+	})
+	t.Run("Returns a bad approval error with more seats than options", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Method: ApprovalMethod, Seats: 3})
+
+		assertValue(t, got, ErrBadApproval)
+	})
+	t.Run("Returns a bad approval error with negative selections", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Method: ApprovalMethod, MaxSelections: -1})
+
+		assertValue(t, got, ErrBadApproval)
+	})
+	t.Run("Opens the session at the creation if no start was informed", func(t *testing.T) {
+		got, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		assertValue(t, got.Start, now)
+		assertValue(t, got.State, StateOpen)
+	})
+	t.Run("Schedules the session to the informed start", func(t *testing.T) {
+		start := now.Add(48 * time.Hour)
+		got, err := service.CreateSession("anID", Params{Start: start, Duration: lasting(time.Hour)})
+
+		assertValue(t, err, nil)
+		assertValue(t, repo.store[got.ID].Start, start)
+		assertValue(t, got.State, StateScheduled)
+		assertValue(t, got.GetExpiration(), start.Add(time.Hour))
+	})
+	t.Run("Returns a bad start error if the start is in the past", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{Start: now.Add(-time.Hour)})
+
+		assertValue(t, got, ErrBadStart)
+	})
+	t.Run("Returns the roster error if the roster does not exist", func(t *testing.T) {
+		_, got := service.CreateSession("anID", Params{RosterID: "notFound"})
+
+		assertValue(t, got.Error(), "Roster not found")
+	})
 	t.Run("Returns the error if there was any error", func(t *testing.T) {
-		_, got := service.CreateSession("error", time.Duration(time.Minute))
+		_, got := service.CreateSession("error", Params{Duration: lasting(time.Minute)})
 		want := errors.New("ops, there was an error")
 
 		assertType(t, got, want)
@@ -92,15 +328,14 @@ func TestFindSession(t *testing.T) {
 	now := time.Now()
 	store := map[string]Session{}
 	clockStub := ClockStub{RightNow: now}
-	pubStub := PublisherStub{CalledWith: []interface{}{}}
-	repo := SessionRepoStub{store}
-	service := sessionService{&repo, &pubStub, &clockStub}
+	repo := SessionRepoStub{store: store}
+	service := sessionService{&repo, &clockStub, Limits{}}
 	t.Run("Returns an session", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) & 5
-		s, _ := service.CreateSession(agendaID, duration)
+		duration := time.Duration(time.Minute) * 5
+		s, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 
-		got, _ := service.FindSession(s.ID)
+		got, _ := service.FindSession(agendaID, s.ID)
 		want := Session{}
 
 		assertType(t, got, want)
@@ -110,71 +345,223 @@ func TestFindSession(t *testing.T) {
 		assertType(t, got.Creation, now)
 		assertValue(t, got.OriginalAgenda, s.OriginalAgenda)
 	})
+	t.Run("Returns the current state of the session", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Start: now.Add(time.Hour), Duration: lasting(time.Minute)})
+
+		got, _ := service.FindSession("anID", s.ID)
+		assertValue(t, got.State, StateScheduled)
+
+		clockStub.RightNow = now.Add(time.Hour)
+		got, _ = service.FindSession("anID", s.ID)
+		assertValue(t, got.State, StateOpen)
+
+		clockStub.RightNow = now.Add(2 * time.Hour)
+		got, _ = service.FindSession("anID", s.ID)
+		assertValue(t, got.State, StateClosed)
+		clockStub.RightNow = now
+	})
 	t.Run("Returns an error if there was an error", func(t *testing.T) {
-		_, err := service.FindSession("notFound")
+		_, err := service.FindSession("anID", "notFound")
 		want := errors.New("Session not found")
 
 		assertType(t, err, want)
 		assertValue(t, err.Error(), want.Error())
 	})
+	t.Run("Returns a session not found error if it belongs to another agenda", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		_, err := service.FindSession("otherID", s.ID)
+
+		assertValue(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	now := time.Now()
+	store := map[string]Session{
+		"closed": {ID: "closed", OriginalAgenda: "anID", Creation: now.Add(-time.Hour), Duration: time.Minute},
+		"open":   {ID: "open", OriginalAgenda: "anID", Creation: now.Add(-time.Second), Duration: time.Minute},
+		"other":  {ID: "other", OriginalAgenda: "otherID", Creation: now, Duration: time.Minute},
+	}
+	clockStub := ClockStub{RightNow: now}
+	repo := SessionRepoStub{store: store}
+	service := sessionService{&repo, &clockStub, Limits{}}
+	t.Run("Returns only the agenda sessions, oldest first", func(t *testing.T) {
+		got, _ := service.ListSessions("anID")
+
+		assertValue(t, len(got), 2)
+		assertValue(t, got[0].ID, "closed")
+		assertValue(t, got[1].ID, "open")
+	})
+	t.Run("Fills the state of each session", func(t *testing.T) {
+		got, _ := service.ListSessions("anID")
+
+		assertValue(t, got[0].State, StateClosed)
+		assertValue(t, got[1].State, StateOpen)
+	})
+	t.Run("Returns an agenda not found error if the agenda does not exist", func(t *testing.T) {
+		_, err := service.ListSessions("notFound")
+
+		assertValue(t, err, agenda.ErrAgendaNotFound)
+	})
+	t.Run("Returns an error if there was an error", func(t *testing.T) {
+		_, err := service.ListSessions("error")
+
+		assertType(t, err, errors.New(""))
+	})
 }
 
 func TestResult(t *testing.T) {
 	now := time.Now()
 	store := map[string]Session{}
 	clockStub := ClockStub{RightNow: now}
-	pubStub := PublisherStub{CalledWith: []interface{}{}}
-	repo := SessionRepoStub{store}
-	service := sessionService{&repo, &pubStub, &clockStub}
+	repo := SessionRepoStub{store: store}
+	service := sessionService{&repo, &clockStub, Limits{}}
 	t.Run("Returns an result", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) & 5
-		s, _ := service.CreateSession(agendaID, duration)
+		duration := time.Duration(time.Minute) * 5
+		s, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 
-		got, _ := service.Result(s.ID)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
 		want := Result{}
 
 		assertType(t, got, want)
 		assertValue(t, got.ID, s.ID)
 		assertValue(t, got.OriginalAgenda, s.OriginalAgenda)
 		assertType(t, got.Count, want.Count)
+		assertValue(t, len(got.Count.Options), len(agenda.DefaultOptions))
 		assertType(t, got.Closed, want.Closed)
 	})
 	t.Run("Returns an result closed result if is session is expired", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) & 5
-		s, _ := service.CreateSession(agendaID, duration)
+		duration := time.Duration(time.Minute) * 5
+		s, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 
 		clockStub.RightNow = now.Add(time.Duration(time.Hour))
-		got, _ := service.Result(s.ID)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
 		want := true
 
 		assertValue(t, got.Closed, want)
 	})
 	t.Run("Returns an result not closed result if is session is not expired", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) & 5
-		s, _ := service.CreateSession(agendaID, duration)
+		duration := time.Duration(time.Minute) * 5
+		s, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 
 		clockStub.RightNow = now.Add(-time.Duration(time.Hour))
-		got, _ := service.Result(s.ID)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
 		want := false
 
 		assertValue(t, got.Closed, want)
 	})
+	t.Run("Returns a pending outcome if the session is not expired", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		clockStub.RightNow = now.Add(-time.Duration(time.Hour))
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Outcome, OutcomePending)
+	})
+	t.Run("Returns the decided outcome if the session is expired", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		clockStub.RightNow = now.Add(time.Duration(time.Hour))
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Outcome, OutcomeApproved)
+		assertValue(t, got.Winner, "S")
+	})
+	t.Run("Returns a void outcome if the session was cancelled", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+		s.State = StateCancelled
+		store[s.ID] = s
+
+		clockStub.RightNow = now
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Closed, true)
+		assertValue(t, got.Void, true)
+		assertValue(t, got.Outcome, OutcomeVoid)
+		assertValue(t, got.Winner, "")
+	})
 	t.Run("Returns count of the votes", func(t *testing.T) {
 		agendaID := "anID"
-		duration := time.Duration(time.Minute) & 5
-		s, _ := service.CreateSession(agendaID, duration)
+		duration := time.Duration(time.Minute) * 5
+		s, _ := service.CreateSession(agendaID, Params{Duration: lasting(duration)})
 
 		clockStub.RightNow = now.Add(-time.Duration(time.Hour))
-		got, _ := service.Result(s.ID)
-		want := Count{3, 2}
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+		want := Count{
+			Options:              []OptionCount{{"S", 3, 3, 0}, {"N", 2, 8, 1}},
+			Abstentions:          1,
+			AbstentionWeight:     2,
+			DelegatedAbstentions: 1,
+		}
+
+		if !reflect.DeepEqual(got.Count, want) {
+			t.Errorf("got %v want %v", got.Count, want)
+		}
+	})
+	t.Run("Decides on the weights if the rule is weighted", func(t *testing.T) {
+		clockStub.RightNow = now
+		s, _ := service.CreateSession("anID", Params{
+			Duration: lasting(time.Minute),
+			Rule:     DecisionRule{Basis: WeightedBasis},
+		})
+
+		clockStub.RightNow = now.Add(time.Hour)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Outcome, OutcomeRejected)
+		assertValue(t, got.Winner, "N")
+		assertValue(t, got.Count.WeightedTurnout(), 13)
+	})
+	t.Run("Elects the runoff winner if the session is ranked", func(t *testing.T) {
+		clockStub.RightNow = now
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute), Method: RankedMethod})
+
+		clockStub.RightNow = now.Add(time.Hour)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Outcome, OutcomeElected)
+		assertValue(t, got.Winner, "S")
+		assertValue(t, len(got.Rounds), 1)
+	})
+	t.Run("Returns the approvals and elects the most approved options", func(t *testing.T) {
+		clockStub.RightNow = now
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute), Method: ApprovalMethod})
+
+		clockStub.RightNow = now.Add(time.Hour)
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		want := []OptionCount{{"S", 2, 2, 0}, {"N", 1, 1, 0}}
+		if !reflect.DeepEqual(got.Approvals, want) {
+			t.Errorf("got %v want %v", got.Approvals, want)
+		}
+		if !reflect.DeepEqual(got.Count.Options, want) {
+			t.Errorf("got %v counted want %v", got.Count.Options, want)
+		}
+		assertValue(t, got.Count.Turnout(), 3)
+		assertValue(t, got.Outcome, OutcomeElected)
+		assertValue(t, got.Winner, "S")
+	})
+	t.Run("Returns the turnout against the roster size", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute), RosterID: "aRoster"})
+
+		got, _ := service.Result(s.OriginalAgenda, s.ID)
+
+		assertValue(t, got.Eligible, 10)
+		assertValue(t, got.TurnoutPercent(), 60.0)
+	})
+	t.Run("Returns a session not found error if it belongs to another agenda", func(t *testing.T) {
+		s, _ := service.CreateSession("anID", Params{Duration: lasting(time.Minute)})
+
+		_, err := service.Result("otherID", s.ID)
 
-		assertValue(t, got.Count, want)
+		assertValue(t, err, ErrSessionNotFound)
 	})
 	t.Run("Returns an error if there was an error", func(t *testing.T) {
-		_, err := service.FindSession("notFound")
+		_, err := service.FindSession("anID", "notFound")
 		want := errors.New("Session not found")
 
 		assertType(t, err, want)
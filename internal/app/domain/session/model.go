@@ -2,29 +2,187 @@ package session
 
 import "time"
 
-// Session Representation of a agenda voting session
+// Voting methods of a session. Plurality ballots choose one option,
+// ranked ballots order every option and are decided by instant runoff,
+// approval ballots approve a set of options and elect the most approved
+const (
+	PluralityMethod = "plurality"
+	RankedMethod    = "ranked"
+	ApprovalMethod  = "approval"
+)
+
+// Session Representation of a agenda voting session, voting opens at the
+// start and lasts for the duration. The state is the one last stored, see
+// StateAt for the state at a given moment
 type Session struct {
 	ID             string
 	OriginalAgenda string
 	Duration       time.Duration
 	Creation       time.Time
+	Start          time.Time
+	Rule           DecisionRule
+	RosterID       string
+	Secret         bool
+	VoteChanges    bool
+	Method         string
+	MaxSelections  int
+	Seats          int
+	State          string
+}
+
+// Params Set of parameters used to open a voting session, the start is
+// optional and schedules the voting to a future time, it opens at the
+// creation otherwise. The session lasts for the duration or until the
+// closing time, only one of them is informed, and for the default
+// duration when neither is. The roster is optional and restricts the
+// session to its members. Secret sessions keep who voted apart from what
+// was voted, VoteChanges lets the associates change or retract their
+// votes while the session is open. The method defaults to plurality.
+// Approval sessions elect as many options as seats, one by default, from
+// ballots approving up to MaxSelections options, any number of them when
+// zero
+type Params struct {
+	Start         time.Time
+	Duration      *time.Duration
+	Closes        time.Time
+	Rule          DecisionRule
+	RosterID      string
+	Secret        bool
+	VoteChanges   bool
+	Method        string
+	MaxSelections int
+	Seats         int
+}
+
+// OpensAt Returns the datetime the session opens for voting, sessions
+// without a start open at the creation
+func (s *Session) OpensAt() time.Time {
+	if s.Start.IsZero() {
+		return s.Creation
+	}
+	return s.Start
 }
 
 // GetExpiration Returns the datetime the session wil expire
 func (s *Session) GetExpiration() time.Time {
-	return s.Creation.Add(s.Duration)
+	return s.OpensAt().Add(s.Duration)
+}
+
+// Ballot Representation of a counted vote along with the voting power
+// of its voter, delegated ballots were cast by a proxy. Ballots of ranked
+// sessions carry the options in order of preference and ballots of
+// approval sessions the approved options, the vote being the first of them
+type Ballot struct {
+	Vote       string
+	Weight     int
+	Delegated  bool
+	Ranking    []string
+	Selections []string
+}
+
+// OptionCount Representation of the votes received by a ballot option,
+// by headcount and weighted. Delegated is the part of the votes cast by
+// proxies
+type OptionCount struct {
+	OptionID  string
+	Votes     int
+	Weight    int
+	Delegated int
 }
 
-// Count Representation of a voting count
+// Count Representation of a voting count, options are kept in the
+// same order they were declared by the agenda. Options of approval
+// sessions count approvals, a ballot counts toward every option it
+// approves, so the ballots approving any option are summed in Approving
 type Count struct {
-	InFavor int
-	Against int
+	Options              []OptionCount
+	Approving            *OptionCount
+	Abstentions          int
+	AbstentionWeight     int
+	DelegatedAbstentions int
 }
 
-// Result Representation of a voting session result
+// Valid Returns the number of votes given to an option, abstentions
+// are not part of it
+func (c *Count) Valid() int {
+	if c.Approving != nil {
+		return c.Approving.Votes
+	}
+	valid := 0
+	for _, o := range c.Options {
+		valid += o.Votes
+	}
+	return valid
+}
+
+// Turnout Returns the number of votes including abstentions
+func (c *Count) Turnout() int {
+	return c.Valid() + c.Abstentions
+}
+
+// Delegated Returns the number of votes cast by proxies including
+// abstentions, the remaining votes were cast directly
+func (c *Count) Delegated() int {
+	if c.Approving != nil {
+		return c.Approving.Delegated + c.DelegatedAbstentions
+	}
+	delegated := c.DelegatedAbstentions
+	for _, o := range c.Options {
+		delegated += o.Delegated
+	}
+	return delegated
+}
+
+// WeightedValid Returns the weight of the votes given to an option,
+// abstentions are not part of it
+func (c *Count) WeightedValid() int {
+	if c.Approving != nil {
+		return c.Approving.Weight
+	}
+	valid := 0
+	for _, o := range c.Options {
+		valid += o.Weight
+	}
+	return valid
+}
+
+// WeightedTurnout Returns the weight of the votes including abstentions
+func (c *Count) WeightedTurnout() int {
+	return c.WeightedValid() + c.AbstentionWeight
+}
+
+// Round Representation of an instant runoff round, the counts of the
+// options still running and the option eliminated at its end, if any
+type Round struct {
+	Options    []OptionCount
+	Eliminated []string
+}
+
+// Result Representation of a voting session result, the outcome
+// stays pending while the session is open. Eligible is the number of
+// associates able to vote, zero when unknown. Ranked sessions count the
+// first preferences and detail the runoff rounds, approval sessions count
+// and detail the approvals of every option. Results of cancelled sessions
+// are closed and void
 type Result struct {
 	ID             string
 	OriginalAgenda string
 	Closed         bool
+	Void           bool
 	Count          Count
+	Rounds         []Round
+	Approvals      []OptionCount
+	Eligible       int
+	Outcome        string
+	Winner         string
+	Winners        []string
+}
+
+// TurnoutPercent Returns the percent of the eligible associates that
+// voted, abstentions included, or zero when the eligible are unknown
+func (r *Result) TurnoutPercent() float64 {
+	if r.Eligible == 0 {
+		return 0
+	}
+	return float64(r.Count.Turnout()) * 100 / float64(r.Eligible)
 }
@@ -19,3 +19,33 @@ func TestGetExpiration(t *testing.T) {
 		assertValue(t, got, want)
 	})
 }
+
+func TestCountTotals(t *testing.T) {
+	c := Count{
+		Options:              []OptionCount{{"S", 3, 30, 1}, {"N", 2, 5, 2}},
+		Abstentions:          4,
+		AbstentionWeight:     7,
+		DelegatedAbstentions: 1,
+	}
+	t.Run("valid votes do not include abstentions", func(t *testing.T) {
+		assertValue(t, c.Valid(), 5)
+	})
+	t.Run("turnout includes abstentions", func(t *testing.T) {
+		assertValue(t, c.Turnout(), 9)
+	})
+	t.Run("delegated votes include abstentions", func(t *testing.T) {
+		assertValue(t, c.Delegated(), 4)
+	})
+	t.Run("weighted totals sum the weights", func(t *testing.T) {
+		assertValue(t, c.WeightedValid(), 35)
+		assertValue(t, c.WeightedTurnout(), 42)
+	})
+	t.Run("approval counts total the approving ballots", func(t *testing.T) {
+		approval := c
+		approval.Approving = &OptionCount{Votes: 4, Weight: 31, Delegated: 2}
+
+		assertValue(t, approval.Turnout(), 8)
+		assertValue(t, approval.Delegated(), 3)
+		assertValue(t, approval.WeightedTurnout(), 38)
+	})
+}
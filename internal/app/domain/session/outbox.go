@@ -0,0 +1,31 @@
+package session
+
+import "time"
+
+// ResultEvent type of the event carrying a session result
+const ResultEvent = "session.result"
+
+// Event statuses inside the outbox
+const (
+	EventPending = "pending"
+	EventSent    = "sent"
+	EventDead    = "dead"
+)
+
+// Event Representation of a domain event waiting to be published
+type Event struct {
+	ID          string
+	Type        string
+	Payload     []byte
+	Status      string
+	Attempts    int
+	LastError   string
+	NextAttempt time.Time
+	Creation    time.Time
+}
+
+// Outbox Persistency interface to the events waiting to be published
+type Outbox interface {
+	FindPendingEvents(time.Time, int) ([]Event, error)
+	UpdateEvent(Event) error
+}
@@ -0,0 +1,125 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
+)
+
+const (
+	relayBatchSize = 100
+	maxBackoff     = time.Hour
+)
+
+// ErrUnknownEvent represents an error caused by an event without handler
+var ErrUnknownEvent = errors.New("Unknown event type")
+
+// Relay describes the outbox relay interface
+type Relay interface {
+	Start()
+	Stop()
+	RelayPendingEvents() error
+}
+
+// NewOutboxRelay creates a relay that drains the outbox to the publisher
+// on every interval, retrying failed events with exponential backoff
+// until maxAttempts, when they are dead lettered
+func NewOutboxRelay(o Outbox, p Publisher, interval time.Duration, maxAttempts int) Relay {
+	return &outboxRelay{
+		outbox:      o,
+		pub:         p,
+		clock:       clock.System{},
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		done:        make(chan struct{}),
+	}
+}
+
+type outboxRelay struct {
+	outbox      Outbox
+	pub         Publisher
+	clock       clock.Clock
+	interval    time.Duration
+	maxAttempts int
+	done        chan struct{}
+	stopOnce    sync.Once
+}
+
+// Start starts draining the outbox in background
+func (r *outboxRelay) Start() {
+	go runEvery(r.interval, r.done, r.RelayPendingEvents)
+}
+
+// Stop stops the background draining
+func (r *outboxRelay) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.done)
+	})
+}
+
+// RelayPendingEvents publishes every pending event whose next attempt is
+// due. Events are marked as sent only after the publisher acknowledges
+// them, so the delivery is at-least-once
+func (r *outboxRelay) RelayPendingEvents() error {
+	events, err := r.outbox.FindPendingEvents(r.clock.Now(), relayBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, e := range events {
+		if err := r.relay(e); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (r *outboxRelay) relay(e Event) error {
+	e.Attempts++
+
+	err := r.dispatch(e)
+	switch {
+	case err == nil:
+		e.Status = EventSent
+		e.LastError = ""
+	case errors.Is(err, ErrUnknownEvent) || e.Attempts >= r.maxAttempts:
+		e.Status = EventDead
+		e.LastError = err.Error()
+	default:
+		e.LastError = err.Error()
+		e.NextAttempt = r.clock.Now().Add(r.backoff(e.Attempts))
+	}
+
+	if updateErr := r.outbox.UpdateEvent(e); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (r *outboxRelay) dispatch(e Event) error {
+	switch e.Type {
+	case ResultEvent:
+		var result Result
+		if err := json.Unmarshal(e.Payload, &result); err != nil {
+			return err
+		}
+		return r.pub.PublishResult(result)
+	default:
+		return ErrUnknownEvent
+	}
+}
+
+func (r *outboxRelay) backoff(attempts int) time.Duration {
+	d := r.interval
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,86 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type OutboxStub struct {
+	events  []Event
+	updated []Event
+}
+
+func (o *OutboxStub) FindPendingEvents(now time.Time, limit int) ([]Event, error) {
+	return o.events, nil
+}
+
+func (o *OutboxStub) UpdateEvent(e Event) error {
+	o.updated = append(o.updated, e)
+	return nil
+}
+
+func TestRelayPendingEvents(t *testing.T) {
+	now := time.Now()
+	clockStub := ClockStub{RightNow: now}
+	payload, _ := json.Marshal(Result{ID: "sessionID"})
+	resultEvent := Event{
+		ID:      "eventID",
+		Type:    ResultEvent,
+		Payload: payload,
+		Status:  EventPending,
+	}
+	t.Run("Publishes the event and marks it as sent", func(t *testing.T) {
+		outbox := OutboxStub{events: []Event{resultEvent}}
+		pubStub := PublisherStub{}
+		relay := outboxRelay{outbox: &outbox, pub: &pubStub, clock: &clockStub, interval: time.Second, maxAttempts: 3}
+
+		err := relay.RelayPendingEvents()
+
+		assertValue(t, err, nil)
+		assertValue(t, pubStub.CalledWith[0].(Result).ID, "sessionID")
+		assertValue(t, outbox.updated[0].Status, EventSent)
+		assertValue(t, outbox.updated[0].Attempts, 1)
+	})
+	t.Run("Schedules a retry with backoff if the publication fails", func(t *testing.T) {
+		e := resultEvent
+		e.Attempts = 1
+		outbox := OutboxStub{events: []Event{e}}
+		want := errors.New("broker down")
+		pubStub := PublisherStub{Err: want}
+		relay := outboxRelay{outbox: &outbox, pub: &pubStub, clock: &clockStub, interval: time.Second, maxAttempts: 3}
+
+		got := relay.RelayPendingEvents()
+
+		assertValue(t, got, want)
+		assertValue(t, outbox.updated[0].Status, EventPending)
+		assertValue(t, outbox.updated[0].Attempts, 2)
+		assertValue(t, outbox.updated[0].LastError, want.Error())
+		assertValue(t, outbox.updated[0].NextAttempt, now.Add(2*time.Second))
+	})
+	t.Run("Dead letters the event after the max attempts", func(t *testing.T) {
+		e := resultEvent
+		e.Attempts = 2
+		outbox := OutboxStub{events: []Event{e}}
+		pubStub := PublisherStub{Err: errors.New("broker down")}
+		relay := outboxRelay{outbox: &outbox, pub: &pubStub, clock: &clockStub, interval: time.Second, maxAttempts: 3}
+
+		relay.RelayPendingEvents()
+
+		assertValue(t, outbox.updated[0].Status, EventDead)
+	})
+	t.Run("Dead letters events of unknown type", func(t *testing.T) {
+		e := resultEvent
+		e.Type = "unknown"
+		outbox := OutboxStub{events: []Event{e}}
+		pubStub := PublisherStub{}
+		relay := outboxRelay{outbox: &outbox, pub: &pubStub, clock: &clockStub, interval: time.Second, maxAttempts: 3}
+
+		got := relay.RelayPendingEvents()
+
+		assertValue(t, got, ErrUnknownEvent)
+		assertValue(t, outbox.updated[0].Status, EventDead)
+		assertValue(t, len(pubStub.CalledWith), 0)
+	})
+}
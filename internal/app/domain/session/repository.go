@@ -1,8 +1,20 @@
 package session
 
+import (
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
 // Repository Persistency interface to serve the Session service
 type Repository interface {
+	FindAgenda(string) (agenda.Agenda, error)
 	FindSession(string) (Session, error)
+	FindSessions(string) ([]Session, error)
 	InsertSession(Session) error
-	FindVotes(Session) ([]string, error)
+	FindRosterSize(string) (int, error)
+	FindVotes(Session) ([]Ballot, error)
+	FindUnpublishedSessions(time.Time) ([]Session, error)
+	MarkResultPublished(Session, Session, Event) error
+	InsertTransition(Session, Session, Transition) error
 }
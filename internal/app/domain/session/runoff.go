@@ -0,0 +1,137 @@
+package session
+
+import "github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+
+// runoff runs the instant runoff rounds of a ranked session. Each round
+// counts every ballot for its most preferred option still running, on the
+// rule basis. An option with more than half of the round votes wins,
+// otherwise the least voted option is eliminated, one per round. The
+// winner is empty when no option was voted or when every option left is
+// tied. Abstentions do not take part in the rounds
+func runoff(options []agenda.Option, ballots []Ballot, rule DecisionRule) ([]Round, string) {
+	running := make([]string, 0, len(options))
+	for _, o := range options {
+		running = append(running, o.ID)
+	}
+
+	var rounds []Round
+	for len(running) > 0 {
+		round := Round{Options: make([]OptionCount, len(running))}
+		position := map[string]int{}
+		for i, id := range running {
+			round.Options[i].OptionID = id
+			position[id] = i
+		}
+
+		for _, b := range ballots {
+			if b.Vote == agenda.Abstention {
+				continue
+			}
+			for _, id := range preferences(b) {
+				i, ok := position[id]
+				if !ok {
+					continue
+				}
+				round.Options[i].Votes++
+				round.Options[i].Weight += b.Weight
+				if b.Delegated {
+					round.Options[i].Delegated++
+				}
+				break
+			}
+		}
+
+		total := 0
+		for _, o := range round.Options {
+			total += rule.votes(o)
+		}
+		for _, o := range round.Options {
+			if total > 0 && rule.votes(o)*2 > total {
+				return append(rounds, round), o.OptionID
+			}
+		}
+
+		tied := least(round, rule)
+		if total == 0 || len(tied) == len(running) {
+			return append(rounds, round), ""
+		}
+		eliminated := breakTie(rounds, tied, rule)
+		round.Eliminated = []string{eliminated}
+		rounds = append(rounds, round)
+
+		remaining := make([]string, 0, len(running)-1)
+		for _, id := range running {
+			if id != eliminated {
+				remaining = append(remaining, id)
+			}
+		}
+		running = remaining
+	}
+	return rounds, ""
+}
+
+// least returns the least voted options of the round, in agenda order
+func least(round Round, rule DecisionRule) []string {
+	var tied []string
+	lowest := -1
+	for _, o := range round.Options {
+		switch votes := rule.votes(o); {
+		case lowest < 0 || votes < lowest:
+			lowest, tied = votes, []string{o.OptionID}
+		case votes == lowest:
+			tied = append(tied, o.OptionID)
+		}
+	}
+	return tied
+}
+
+// breakTie picks the option to eliminate among the tied ones, the least
+// voted in the latest previous round that tells them apart and otherwise
+// the last one in agenda order
+func breakTie(previous []Round, tied []string, rule DecisionRule) string {
+	for i := len(previous) - 1; i >= 0 && len(tied) > 1; i-- {
+		tied = least(previous[i].only(tied), rule)
+	}
+	return tied[len(tied)-1]
+}
+
+// only returns the round with the counts of the informed options alone,
+// kept in the order of the round
+func (r Round) only(ids []string) Round {
+	wanted := map[string]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var kept Round
+	for _, o := range r.Options {
+		if wanted[o.OptionID] {
+			kept.Options = append(kept.Options, o)
+		}
+	}
+	return kept
+}
+
+// preferences returns the options of the ballot in order of preference,
+// ballots cast without a ranking only prefer their vote
+func preferences(b Ballot) []string {
+	if len(b.Ranking) > 0 {
+		return b.Ranking
+	}
+	return []string{b.Vote}
+}
+
+// elect decides a ranked session from the runoff winner, the quorum is
+// checked against the first preferences. Without a winner the session is
+// tied when there were votes and rejected otherwise
+func (r *DecisionRule) elect(c Count, winner string) (outcome string, elected string) {
+	if !r.hasQuorum(c) {
+		return OutcomeNoQuorum, ""
+	}
+	if winner != "" {
+		return OutcomeElected, winner
+	}
+	if r.valid(c) > 0 {
+		return OutcomeTied, ""
+	}
+	return OutcomeRejected, ""
+}
@@ -0,0 +1,129 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+)
+
+func TestRunoff(t *testing.T) {
+	options := []agenda.Option{{ID: "A"}, {ID: "B"}, {ID: "C"}}
+	ranked := func(weight int, ranking ...string) Ballot {
+		return Ballot{Vote: ranking[0], Weight: weight, Ranking: ranking}
+	}
+	headcount := DecisionRule{Majority: SimpleMajority, Basis: HeadcountBasis}
+	t.Run("elects an option with a majority of the first preferences", func(t *testing.T) {
+		rounds, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), ranked(1, "A", "C", "B"), ranked(1, "B", "A", "C"),
+		}, headcount)
+
+		assertValue(t, winner, "A")
+		assertValue(t, len(rounds), 1)
+		assertValue(t, len(rounds[0].Eliminated), 0)
+	})
+	t.Run("transfers the votes of the eliminated options", func(t *testing.T) {
+		rounds, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), ranked(1, "A", "C", "B"),
+			ranked(1, "B", "C", "A"), ranked(1, "B", "A", "C"),
+			ranked(1, "C", "B", "A"),
+		}, headcount)
+
+		assertValue(t, winner, "B")
+		assertValue(t, len(rounds), 2)
+		if !reflect.DeepEqual(rounds[0].Eliminated, []string{"C"}) {
+			t.Errorf("got %v eliminated in the first round", rounds[0].Eliminated)
+		}
+		want := []OptionCount{{"A", 2, 2, 0}, {"B", 3, 3, 0}}
+		if !reflect.DeepEqual(rounds[1].Options, want) {
+			t.Errorf("got %v want %v", rounds[1].Options, want)
+		}
+	})
+	t.Run("eliminates one option per round, the last one in agenda order among the tied", func(t *testing.T) {
+		rounds, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), ranked(1, "A", "C", "B"),
+			ranked(1, "B", "A", "C"), ranked(1, "C", "A", "B"),
+		}, headcount)
+
+		assertValue(t, winner, "A")
+		assertValue(t, len(rounds), 2)
+		if !reflect.DeepEqual(rounds[0].Eliminated, []string{"C"}) {
+			t.Errorf("got %v eliminated in the first round", rounds[0].Eliminated)
+		}
+		want := []OptionCount{{"A", 3, 3, 0}, {"B", 1, 1, 0}}
+		if !reflect.DeepEqual(rounds[1].Options, want) {
+			t.Errorf("got %v want %v", rounds[1].Options, want)
+		}
+	})
+	t.Run("breaks the ties by the counts of the previous rounds", func(t *testing.T) {
+		four := []agenda.Option{{ID: "A"}, {ID: "B"}, {ID: "C"}, {ID: "D"}}
+		a, b, c := ranked(1, "A", "B", "C", "D"), ranked(1, "B", "C", "A", "D"), ranked(1, "C", "A", "B", "D")
+		rounds, winner := runoff(four, []Ballot{
+			a, a, a, a, a, b, b, c, c, c, ranked(1, "D", "B", "C", "A"),
+		}, headcount)
+
+		assertValue(t, winner, "C")
+		assertValue(t, len(rounds), 3)
+		if !reflect.DeepEqual(rounds[0].Eliminated, []string{"D"}) {
+			t.Errorf("got %v eliminated in the first round", rounds[0].Eliminated)
+		}
+		if !reflect.DeepEqual(rounds[1].Eliminated, []string{"B"}) {
+			t.Errorf("got %v eliminated in the second round", rounds[1].Eliminated)
+		}
+	})
+	t.Run("has no winner if every option left is tied", func(t *testing.T) {
+		rounds, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), ranked(1, "B", "A", "C"), ranked(1, "C", "A", "B"),
+		}, headcount)
+
+		assertValue(t, winner, "")
+		assertValue(t, len(rounds), 1)
+		assertValue(t, len(rounds[0].Eliminated), 0)
+	})
+	t.Run("weighs the rounds on the rule basis", func(t *testing.T) {
+		_, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), ranked(1, "A", "C", "B"), ranked(5, "B", "A", "C"),
+		}, DecisionRule{Basis: WeightedBasis})
+
+		assertValue(t, winner, "B")
+	})
+	t.Run("ignores the abstentions", func(t *testing.T) {
+		rounds, winner := runoff(options, []Ballot{
+			ranked(1, "A", "B", "C"), {Vote: agenda.Abstention, Weight: 1}, {Vote: agenda.Abstention, Weight: 1},
+		}, headcount)
+
+		assertValue(t, winner, "A")
+		assertValue(t, rounds[0].Options[0].Votes, 1)
+	})
+	t.Run("has no winner and no eliminations without votes", func(t *testing.T) {
+		rounds, winner := runoff(options, nil, headcount)
+
+		assertValue(t, winner, "")
+		assertValue(t, len(rounds), 1)
+		assertValue(t, len(rounds[0].Eliminated), 0)
+	})
+}
+
+func TestElect(t *testing.T) {
+	count := Count{Options: []OptionCount{{"A", 2, 2, 0}, {"B", 1, 1, 0}}}
+	t.Run("elects the runoff winner", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority}
+		outcome, winner := r.elect(count, "A")
+
+		assertValue(t, outcome, OutcomeElected)
+		assertValue(t, winner, "A")
+	})
+	t.Run("ties when the runoff has no winner", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority}
+		outcome, _ := r.elect(count, "")
+
+		assertValue(t, outcome, OutcomeTied)
+	})
+	t.Run("requires the quorum", func(t *testing.T) {
+		r := DecisionRule{Majority: SimpleMajority, QuorumVoters: 5}
+		outcome, winner := r.elect(count, "A")
+
+		assertValue(t, outcome, OutcomeNoQuorum)
+		assertValue(t, winner, "")
+	})
+}
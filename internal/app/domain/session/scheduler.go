@@ -0,0 +1,121 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
+)
+
+// Scheduler describes the result publication scheduler interface
+type Scheduler interface {
+	Start()
+	Stop()
+	PublishPendingResults() error
+}
+
+// NewResultScheduler creates a scheduler that enqueues the results of the
+// expired sessions on boot and on every interval
+func NewResultScheduler(s Service, r Repository, interval time.Duration) Scheduler {
+	return &resultScheduler{
+		service:  s,
+		repo:     r,
+		clock:    clock.System{},
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+type resultScheduler struct {
+	service  Service
+	repo     Repository
+	clock    clock.Clock
+	interval time.Duration
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Start starts publishing pending results in background
+func (s *resultScheduler) Start() {
+	go runEvery(s.interval, s.done, s.PublishPendingResults)
+}
+
+// Stop stops the background publication
+func (s *resultScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// PublishPendingResults enqueues the result of every ended session not
+// yet published. The session is moved to published in the same
+// transaction the result event is written to the outbox, so each result
+// is enqueued only once. Cancelled sessions keep their state, their void
+// result is published all the same. Sessions changed meanwhile are left
+// for the next run
+func (s *resultScheduler) PublishPendingResults() error {
+	sessions, err := s.repo.FindUnpublishedSessions(s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, session := range sessions {
+		if err := s.publish(session); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (s *resultScheduler) publish(found Session) error {
+	now := s.clock.Now()
+	session := found
+	session.State = session.StateAt(now)
+	if session.State != StateCancelled {
+		if err := session.Apply(PublishAction); err != nil {
+			return err
+		}
+	}
+
+	result, err := s.service.Result(session.OriginalAgenda, session.ID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.MarkResultPublished(found, session, Event{
+		ID:          uuid.New().String(),
+		Type:        ResultEvent,
+		Payload:     payload,
+		Status:      EventPending,
+		NextAttempt: now,
+		Creation:    now,
+	})
+	if errors.Is(err, ErrBadTransition) {
+		return nil
+	}
+	return err
+}
+
+func runEvery(interval time.Duration, done <-chan struct{}, fn func() error) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		fn()
+		select {
+		case <-t.C:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPublishPendingResults(t *testing.T) {
+	now := time.Now()
+	clockStub := ClockStub{RightNow: now}
+	expired := Session{
+		ID:             "expired",
+		OriginalAgenda: "anID",
+		Duration:       time.Minute,
+		Creation:       now.Add(-time.Hour),
+	}
+	open := Session{
+		ID:             "open",
+		OriginalAgenda: "anID",
+		Duration:       time.Hour,
+		Creation:       now,
+	}
+	t.Run("Enqueues the result of expired sessions only", func(t *testing.T) {
+		repo := SessionRepoStub{store: map[string]Session{expired.ID: expired, open.ID: open}}
+		service := sessionService{&repo, &clockStub, Limits{}}
+		scheduler := resultScheduler{service: &service, repo: &repo, clock: &clockStub}
+
+		err := scheduler.PublishPendingResults()
+
+		assertValue(t, err, nil)
+		assertValue(t, len(repo.events), 1)
+		assertValue(t, repo.published[expired.ID], true)
+		assertValue(t, repo.published[open.ID], false)
+		assertValue(t, repo.store[expired.ID].State, StatePublished)
+		assertValue(t, repo.store[open.ID].State, "")
+	})
+	t.Run("Enqueues a pending result event", func(t *testing.T) {
+		repo := SessionRepoStub{store: map[string]Session{expired.ID: expired}}
+		service := sessionService{&repo, &clockStub, Limits{}}
+		scheduler := resultScheduler{service: &service, repo: &repo, clock: &clockStub}
+
+		scheduler.PublishPendingResults()
+		got := repo.events[0]
+		var result Result
+		json.Unmarshal(got.Payload, &result)
+
+		assertValue(t, got.Type, ResultEvent)
+		assertValue(t, got.Status, EventPending)
+		assertValue(t, got.NextAttempt, now)
+		assertValue(t, result.ID, expired.ID)
+	})
+	t.Run("Enqueues the void result of cancelled sessions", func(t *testing.T) {
+		cancelled := open
+		cancelled.ID, cancelled.State = "cancelled", StateCancelled
+		repo := SessionRepoStub{store: map[string]Session{cancelled.ID: cancelled}}
+		service := sessionService{&repo, &clockStub, Limits{}}
+		scheduler := resultScheduler{service: &service, repo: &repo, clock: &clockStub}
+
+		scheduler.PublishPendingResults()
+		var result Result
+		json.Unmarshal(repo.events[0].Payload, &result)
+
+		assertValue(t, result.Void, true)
+		assertValue(t, result.Outcome, OutcomeVoid)
+		assertValue(t, repo.store[cancelled.ID].State, StateCancelled)
+	})
+	t.Run("Enqueues each result only once", func(t *testing.T) {
+		repo := SessionRepoStub{store: map[string]Session{expired.ID: expired}}
+		service := sessionService{&repo, &clockStub, Limits{}}
+		scheduler := resultScheduler{service: &service, repo: &repo, clock: &clockStub}
+
+		scheduler.PublishPendingResults()
+		scheduler.PublishPendingResults()
+
+		assertValue(t, len(repo.events), 1)
+	})
+}
@@ -4,7 +4,12 @@ import "time"
 
 // Service describes the agenda service interface
 type Service interface {
-	CreateSession(string, time.Duration) (Session, error)
-	FindSession(string) (Session, error)
-	Result(string) (Result, error)
+	CreateSession(string, Params) (Session, error)
+	FindSession(string, string) (Session, error)
+	ListSessions(string) ([]Session, error)
+	Result(string, string) (Result, error)
+	CloseSession(string, string, string) (Session, error)
+	ExtendSession(string, string, time.Duration, string) (Session, error)
+	CancelSession(string, string, string) (Session, error)
+	ReopenSession(string, string, time.Duration, string) (Session, error)
 }
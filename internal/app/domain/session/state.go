@@ -0,0 +1,90 @@
+package session
+
+import (
+	"errors"
+	"time"
+)
+
+// Session states, a session is scheduled until its start, open until its
+// expiration and closed until its result is published. Scheduled and open
+// sessions can be cancelled instead
+const (
+	StateScheduled = "scheduled"
+	StateOpen      = "open"
+	StateClosed    = "closed"
+	StatePublished = "published"
+	StateCancelled = "cancelled"
+)
+
+// Actions moving a session between states, besides opening and closing
+// in time
+const (
+	CloseAction   = "close"
+	ExtendAction  = "extend"
+	CancelAction  = "cancel"
+	ReopenAction  = "reopen"
+	PublishAction = "publish"
+)
+
+// ErrBadTransition represents an error caused by an action not allowed in
+// the current state of the session
+var ErrBadTransition = errors.New("The session state does not allow this action")
+
+// transitions maps each action to the states it is allowed from and the
+// state it leads to, actions leading nowhere keep the state
+var transitions = map[string]struct {
+	from []string
+	to   string
+}{
+	CloseAction:   {from: []string{StateOpen}, to: StateClosed},
+	ExtendAction:  {from: []string{StateScheduled, StateOpen}},
+	CancelAction:  {from: []string{StateScheduled, StateOpen}, to: StateCancelled},
+	ReopenAction:  {from: []string{StateClosed, StatePublished}, to: StateOpen},
+	PublishAction: {from: []string{StateClosed}, to: StatePublished},
+}
+
+// Apply Moves the session to the state the action leads to, it fails with
+// ErrBadTransition if the current state does not allow the action
+func (s *Session) Apply(action string) error {
+	t, ok := transitions[action]
+	if !ok {
+		return ErrBadTransition
+	}
+	for _, from := range t.from {
+		if s.State != from {
+			continue
+		}
+		if t.to != "" {
+			s.State = t.to
+		}
+		return nil
+	}
+	return ErrBadTransition
+}
+
+// StateAt Returns the state the session reaches at the informed moment,
+// from its stored state. Scheduled sessions open at their start and open
+// sessions close at their expiration, sessions not stored yet are scheduled
+func (s *Session) StateAt(t time.Time) string {
+	state := s.State
+	if state == "" {
+		state = StateScheduled
+	}
+	if state == StateScheduled && !t.Before(s.OpensAt()) {
+		state = StateOpen
+	}
+	if state == StateOpen && t.After(s.GetExpiration()) {
+		state = StateClosed
+	}
+	return state
+}
+
+// EndedAt Returns whether the voting of the session has ended at the
+// informed moment, either closed or cancelled
+func (s *Session) EndedAt(t time.Time) bool {
+	switch s.StateAt(t) {
+	case StateClosed, StatePublished, StateCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateAt(t *testing.T) {
+	now := time.Now()
+	s := Session{
+		Creation: now,
+		Duration: time.Minute,
+	}
+	t.Run("is scheduled before the creation", func(t *testing.T) {
+		assertValue(t, s.StateAt(now.Add(-time.Second)), StateScheduled)
+	})
+	t.Run("is open until the expiration", func(t *testing.T) {
+		assertValue(t, s.StateAt(now), StateOpen)
+		assertValue(t, s.StateAt(s.GetExpiration()), StateOpen)
+	})
+	t.Run("is closed after the expiration", func(t *testing.T) {
+		assertValue(t, s.StateAt(s.GetExpiration().Add(time.Second)), StateClosed)
+	})
+	t.Run("is scheduled until the start", func(t *testing.T) {
+		scheduled := Session{Creation: now, Start: now.Add(time.Hour), Duration: time.Minute}
+
+		assertValue(t, scheduled.StateAt(now.Add(time.Minute)), StateScheduled)
+		assertValue(t, scheduled.StateAt(scheduled.Start), StateOpen)
+		assertValue(t, scheduled.GetExpiration(), now.Add(time.Hour+time.Minute))
+	})
+	t.Run("keeps the states not reached in time", func(t *testing.T) {
+		for _, state := range []string{StateClosed, StatePublished, StateCancelled} {
+			stored := s
+			stored.State = state
+
+			assertValue(t, stored.StateAt(now), state)
+		}
+	})
+	t.Run("does not go back to open once closed", func(t *testing.T) {
+		closed := s
+		closed.State = StateClosed
+
+		assertValue(t, closed.StateAt(now.Add(-time.Second)), StateClosed)
+	})
+}
+
+func TestEndedAt(t *testing.T) {
+	now := time.Now()
+	s := Session{Creation: now, Duration: time.Minute, State: StateOpen}
+	t.Run("has not ended while open", func(t *testing.T) {
+		assertValue(t, s.EndedAt(now), false)
+	})
+	t.Run("has ended after the expiration", func(t *testing.T) {
+		assertValue(t, s.EndedAt(now.Add(time.Hour)), true)
+	})
+	t.Run("has ended once cancelled", func(t *testing.T) {
+		cancelled := s
+		cancelled.State = StateCancelled
+
+		assertValue(t, cancelled.EndedAt(now), true)
+	})
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		action string
+		from   string
+		want   string
+		err    error
+	}{
+		{CloseAction, StateOpen, StateClosed, nil},
+		{CloseAction, StateScheduled, StateScheduled, ErrBadTransition},
+		{ExtendAction, StateScheduled, StateScheduled, nil},
+		{ExtendAction, StateOpen, StateOpen, nil},
+		{ExtendAction, StateClosed, StateClosed, ErrBadTransition},
+		{CancelAction, StateScheduled, StateCancelled, nil},
+		{CancelAction, StateOpen, StateCancelled, nil},
+		{CancelAction, StatePublished, StatePublished, ErrBadTransition},
+		{ReopenAction, StateClosed, StateOpen, nil},
+		{ReopenAction, StatePublished, StateOpen, nil},
+		{ReopenAction, StateCancelled, StateCancelled, ErrBadTransition},
+		{ReopenAction, StateScheduled, StateScheduled, ErrBadTransition},
+		{PublishAction, StateClosed, StatePublished, nil},
+		{PublishAction, StateOpen, StateOpen, ErrBadTransition},
+		{"unknown", StateOpen, StateOpen, ErrBadTransition},
+	}
+	for _, c := range cases {
+		t.Run(c.action+" from "+c.from, func(t *testing.T) {
+			s := Session{State: c.from}
+
+			err := s.Apply(c.action)
+
+			assertValue(t, err, c.err)
+			assertValue(t, s.State, c.want)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrBadExtension represents an error caused by extending or reopening
+	// a session for a non positive time, beyond the maximum extension or
+	// extending it beyond the maximum duration
+	ErrBadExtension = errors.New("Invalid extension. Must be positive, up to the maximum extension and keep the session within the maximum duration")
+	// ErrReopenWindowExpired represents an error caused by reopening a
+	// session closed for longer than the reopen window
+	ErrReopenWindowExpired = errors.New("The session closed too long ago to be reopened")
+)
+
+// Limits Bounds of the sessions and of their administrative changes, a
+// session lasts from MinDuration up to MaxDuration, unbounded when zero. It
+// is extended or reopened for up to MaxExtension at a time and can only be
+// reopened within ReopenWindow of its closing
+type Limits struct {
+	MinDuration  time.Duration
+	MaxDuration  time.Duration
+	MaxExtension time.Duration
+	ReopenWindow time.Duration
+}
+
+// Transition Representation of an administrative change to a session,
+// from a state to another. Closes is when the session closes after it
+type Transition struct {
+	ID        string
+	SessionID string
+	Action    string
+	From      string
+	To        string
+	Reason    string
+	Closes    time.Time
+	Creation  time.Time
+}
+
+// CloseSession closes an open session before its expiration
+func (s *sessionService) CloseSession(agendaID, id, reason string) (Session, error) {
+	return s.transit(agendaID, id, CloseAction, reason, func(session *Session, now time.Time) error {
+		session.Duration = now.Sub(session.OpensAt())
+		return nil
+	})
+}
+
+// ExtendSession postpones the closing of a scheduled or open session, the
+// extensions added up keep it within the maximum duration
+func (s *sessionService) ExtendSession(agendaID, id string, d time.Duration, reason string) (Session, error) {
+	return s.transit(agendaID, id, ExtendAction, reason, func(session *Session, now time.Time) error {
+		if err := s.checkExtension(d); err != nil {
+			return err
+		}
+		if s.limits.MaxDuration > 0 && d > s.limits.MaxDuration-session.Duration {
+			return ErrBadExtension
+		}
+		session.Duration += d
+		return nil
+	})
+}
+
+// CancelSession cancels a scheduled or open session, voting stops and
+// its result is void
+func (s *sessionService) CancelSession(agendaID, id, reason string) (Session, error) {
+	return s.transit(agendaID, id, CancelAction, reason, nil)
+}
+
+// ReopenSession opens a closed session again for the informed time, as
+// long as it closed within the reopen window and stays within the maximum
+// duration. Its result is published again once it closes
+func (s *sessionService) ReopenSession(agendaID, id string, d time.Duration, reason string) (Session, error) {
+	return s.transit(agendaID, id, ReopenAction, reason, func(session *Session, now time.Time) error {
+		if now.Sub(session.GetExpiration()) > s.limits.ReopenWindow {
+			return ErrReopenWindowExpired
+		}
+		if err := s.checkExtension(d); err != nil {
+			return err
+		}
+		duration := now.Add(d).Sub(session.OpensAt())
+		if s.limits.MaxDuration > 0 && duration > s.limits.MaxDuration {
+			return ErrBadExtension
+		}
+		session.Duration = duration
+		return nil
+	})
+}
+
+func (s *sessionService) checkExtension(d time.Duration) error {
+	if d <= 0 || d > s.limits.MaxExtension {
+		return ErrBadExtension
+	}
+	return nil
+}
+
+// transit applies an administrative action to a session, once its state
+// allows it, along with the optional change and stores the changed session
+// with the transition. It is stored only if the session is still as it
+// was found, ErrBadTransition otherwise
+func (s *sessionService) transit(agendaID, id, action, reason string, change func(*Session, time.Time) error) (Session, error) {
+	found, err := s.findStored(agendaID, id)
+	if err != nil {
+		return Session{}, err
+	}
+
+	now := s.clock.Now()
+	session := found
+	session.State = session.StateAt(now)
+	from := session.State
+	if err := session.Apply(action); err != nil {
+		return Session{}, err
+	}
+	if change != nil {
+		if err := change(&session, now); err != nil {
+			return Session{}, err
+		}
+	}
+
+	t := Transition{
+		ID:        uuid.New().String(),
+		SessionID: session.ID,
+		Action:    action,
+		From:      from,
+		To:        session.State,
+		Reason:    reason,
+		Closes:    session.GetExpiration(),
+		Creation:  now,
+	}
+	if err := s.repo.InsertTransition(found, session, t); err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
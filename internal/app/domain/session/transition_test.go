@@ -0,0 +1,189 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTransitions(t *testing.T) {
+	now := time.Now()
+	clockStub := ClockStub{RightNow: now}
+	limits := Limits{MaxExtension: time.Hour, ReopenWindow: time.Hour}
+	open := Session{
+		ID:             "open",
+		OriginalAgenda: "anID",
+		Duration:       time.Hour,
+		Creation:       now.Add(-time.Minute),
+	}
+	scheduled := Session{
+		ID:             "scheduled",
+		OriginalAgenda: "anID",
+		Duration:       time.Hour,
+		Creation:       now,
+		Start:          now.Add(time.Hour),
+	}
+	closed := Session{
+		ID:             "closed",
+		OriginalAgenda: "anID",
+		Duration:       time.Minute,
+		Creation:       now.Add(-10 * time.Minute),
+	}
+	stale := Session{
+		ID:             "stale",
+		OriginalAgenda: "anID",
+		Duration:       time.Minute,
+		Creation:       now.Add(-2 * time.Hour),
+	}
+	cancelled := open
+	cancelled.ID, cancelled.State = "cancelled", StateCancelled
+	setup := func() (*SessionRepoStub, sessionService) {
+		repo := SessionRepoStub{store: map[string]Session{
+			open.ID:      open,
+			scheduled.ID: scheduled,
+			closed.ID:    closed,
+			stale.ID:     stale,
+			cancelled.ID: cancelled,
+		}}
+		return &repo, sessionService{&repo, &clockStub, limits}
+	}
+	t.Run("Closes an open session right away", func(t *testing.T) {
+		repo, service := setup()
+
+		got, err := service.CloseSession("anID", open.ID, "a reason")
+
+		assertValue(t, err, nil)
+		assertValue(t, got.State, StateClosed)
+		assertValue(t, got.GetExpiration(), now)
+		assertValue(t, repo.store[open.ID].Duration, time.Minute)
+	})
+	t.Run("Records the transition of the session", func(t *testing.T) {
+		repo, service := setup()
+
+		service.CloseSession("anID", open.ID, "a reason")
+		got := repo.transitions[0]
+
+		assertValue(t, got.SessionID, open.ID)
+		assertValue(t, got.Action, CloseAction)
+		assertValue(t, got.From, StateOpen)
+		assertValue(t, got.To, StateClosed)
+		assertValue(t, got.Reason, "a reason")
+		assertValue(t, got.Closes, now)
+		assertValue(t, got.Creation, now)
+	})
+	t.Run("Does not close a session that is not open", func(t *testing.T) {
+		repo, service := setup()
+
+		_, err := service.CloseSession("anID", scheduled.ID, "")
+
+		assertValue(t, err, ErrBadTransition)
+		assertValue(t, len(repo.transitions), 0)
+	})
+	t.Run("Extends scheduled and open sessions", func(t *testing.T) {
+		_, service := setup()
+
+		for _, s := range []Session{open, scheduled} {
+			got, err := service.ExtendSession("anID", s.ID, 30*time.Minute, "")
+
+			assertValue(t, err, nil)
+			assertValue(t, got.GetExpiration(), s.GetExpiration().Add(30*time.Minute))
+		}
+	})
+	t.Run("Does not extend beyond the maximum extension", func(t *testing.T) {
+		_, service := setup()
+
+		for _, d := range []time.Duration{0, -time.Minute, 2 * time.Hour} {
+			_, err := service.ExtendSession("anID", open.ID, d, "")
+
+			assertValue(t, err, ErrBadExtension)
+		}
+	})
+	t.Run("Does not extend beyond the maximum duration however many times", func(t *testing.T) {
+		repo, service := setup()
+		service.limits.MaxDuration = 2 * time.Hour
+
+		_, first := service.ExtendSession("anID", open.ID, time.Hour, "")
+		_, second := service.ExtendSession("anID", open.ID, time.Minute, "")
+
+		assertValue(t, first, nil)
+		assertValue(t, second, ErrBadExtension)
+		assertValue(t, repo.store[open.ID].Duration, 2*time.Hour)
+	})
+	t.Run("Does not extend a closed session", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.ExtendSession("anID", closed.ID, time.Minute, "")
+
+		assertValue(t, err, ErrBadTransition)
+	})
+	t.Run("Cancels scheduled and open sessions", func(t *testing.T) {
+		repo, service := setup()
+
+		for _, s := range []Session{open, scheduled} {
+			got, err := service.CancelSession("anID", s.ID, "")
+
+			assertValue(t, err, nil)
+			assertValue(t, got.State, StateCancelled)
+			assertValue(t, repo.store[s.ID].State, StateCancelled)
+		}
+	})
+	t.Run("Does not change a cancelled session", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.CancelSession("anID", cancelled.ID, "")
+		assertValue(t, err, ErrBadTransition)
+		_, err = service.ReopenSession("anID", cancelled.ID, time.Minute, "")
+		assertValue(t, err, ErrBadTransition)
+	})
+	t.Run("Reopens a closed session for the informed time", func(t *testing.T) {
+		repo, service := setup()
+		repo.published = map[string]bool{closed.ID: true}
+
+		got, err := service.ReopenSession("anID", closed.ID, 30*time.Minute, "")
+
+		assertValue(t, err, nil)
+		assertValue(t, got.State, StateOpen)
+		assertValue(t, got.GetExpiration(), now.Add(30*time.Minute))
+		assertValue(t, repo.published[closed.ID], false)
+	})
+	t.Run("Reopens a session whose result was published", func(t *testing.T) {
+		repo, service := setup()
+		published := closed
+		published.State = StatePublished
+		repo.store[closed.ID] = published
+
+		got, err := service.ReopenSession("anID", closed.ID, 30*time.Minute, "")
+
+		assertValue(t, err, nil)
+		assertValue(t, got.State, StateOpen)
+	})
+	t.Run("Does not reopen beyond the maximum duration", func(t *testing.T) {
+		repo, service := setup()
+		service.limits.MaxDuration = 30 * time.Minute
+
+		_, err := service.ReopenSession("anID", closed.ID, 30*time.Minute, "")
+
+		assertValue(t, err, ErrBadExtension)
+		assertValue(t, repo.store[closed.ID].Duration, time.Minute)
+	})
+	t.Run("Does not reopen a session closed beyond the reopen window", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.ReopenSession("anID", stale.ID, time.Minute, "")
+
+		assertValue(t, err, ErrReopenWindowExpired)
+	})
+	t.Run("Does not reopen an open session", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.ReopenSession("anID", open.ID, time.Minute, "")
+
+		assertValue(t, err, ErrBadTransition)
+	})
+	t.Run("Returns a Session Not Found error if it belongs to another agenda", func(t *testing.T) {
+		_, service := setup()
+
+		_, err := service.CloseSession("otherID", open.ID, "")
+
+		assertValue(t, err, ErrSessionNotFound)
+	})
+}
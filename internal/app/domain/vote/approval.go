@@ -0,0 +1,72 @@
+package vote
+
+import (
+	"errors"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+// ErrBadSelections represents an error caused by an approval vote without
+// options, above the session maximum, repeating or outside the agenda
+// options, or by a vote of an approval session without selections
+var ErrBadSelections = errors.New("Votes of approval sessions must approve distinct agenda options, at least one and up to the session maximum")
+
+// CreateApprovalVote creates the vote of an approval session, approving
+// every selected option. Selections are stored in the agenda order, the
+// vote being the first of them
+func (s *voteService) CreateApprovalVote(id, agendaID, sessionID, document string, selections []string) (Vote, error) {
+	sess, err := s.findSession(agendaID, sessionID)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	approved, err := s.checkSelections(sess, selections)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	return s.cast(sess, Vote{
+		AssociateID: id,
+		SessionID:   sessionID,
+		Document:    NormalizeDocument(document),
+		Vote:        approved[0],
+		Selections:  approved,
+	})
+}
+
+// checkSelections returns the selections in the agenda order, or
+// ErrBadVoteFormat if the session is not an approval session and
+// ErrBadSelections if the selections are not valid for it
+func (s *voteService) checkSelections(sess session.Session, selections []string) ([]string, error) {
+	if sess.Method != session.ApprovalMethod {
+		return nil, ErrBadVoteFormat
+	}
+
+	a, err := s.repo.FindAgenda(sess.OriginalAgenda)
+	if err != nil {
+		return nil, err
+	}
+	max := sess.MaxSelections
+	if max == 0 {
+		max = len(a.Options)
+	}
+	if len(selections) == 0 || len(selections) > max {
+		return nil, ErrBadSelections
+	}
+
+	selected := map[string]bool{}
+	for _, id := range selections {
+		if selected[id] || !a.HasOption(id) {
+			return nil, ErrBadSelections
+		}
+		selected[id] = true
+	}
+
+	approved := make([]string, 0, len(selections))
+	for _, o := range a.Options {
+		if selected[o.ID] {
+			approved = append(approved, o.ID)
+		}
+	}
+	return approved, nil
+}
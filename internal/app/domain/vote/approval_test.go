@@ -0,0 +1,79 @@
+package vote
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+func TestCreateApprovalVote(t *testing.T) {
+	agendaID := "agendaID"
+	sStore := map[string]session.Session{
+		"approval": {
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Duration:       time.Hour,
+			Method:         session.ApprovalMethod,
+			Seats:          1,
+		},
+		"single": {
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Duration:       time.Hour,
+			Method:         session.ApprovalMethod,
+			MaxSelections:  1,
+			Seats:          1,
+		},
+		"plurality": {
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Duration:       time.Hour,
+			Method:         session.PluralityMethod,
+		},
+	}
+	repo := VoteRepoStub{sStore, map[string]Vote{}, map[string]Vote{}, nil, nil}
+	clockStub := ClockStub{RightNow: time.Now()}
+	service := voteService{&repo, DocValidatorStub{}, &clockStub, 0}
+	t.Run("Stores the selections in the agenda order", func(t *testing.T) {
+		got, err := service.CreateApprovalVote("anID", agendaID, "approval", "01791229005", []string{"N", "S"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "S")
+		if !reflect.DeepEqual(repo.voteStore["anID"].Selections, []string{"S", "N"}) {
+			t.Errorf("got %v stored", repo.voteStore["anID"].Selections)
+		}
+	})
+	t.Run("Returns a bad selections error without selections", func(t *testing.T) {
+		_, err := service.CreateApprovalVote("otherID", agendaID, "approval", "01791229005", nil)
+
+		assertValue(t, err, ErrBadSelections)
+	})
+	t.Run("Returns a bad selections error above the session maximum", func(t *testing.T) {
+		_, err := service.CreateApprovalVote("otherID", agendaID, "single", "01791229005", []string{"S", "N"})
+
+		assertValue(t, err, ErrBadSelections)
+	})
+	t.Run("Returns a bad selections error on repeated options", func(t *testing.T) {
+		_, err := service.CreateApprovalVote("otherID", agendaID, "approval", "01791229005", []string{"S", "S"})
+
+		assertValue(t, err, ErrBadSelections)
+	})
+	t.Run("Returns a bad selections error on unknown options", func(t *testing.T) {
+		_, err := service.CreateApprovalVote("otherID", agendaID, "approval", "01791229005", []string{agenda.Abstention})
+
+		assertValue(t, err, ErrBadSelections)
+	})
+	t.Run("Returns a bad vote format error if the session is not an approval session", func(t *testing.T) {
+		_, err := service.CreateApprovalVote("otherID", agendaID, "plurality", "01791229005", []string{"S"})
+
+		assertValue(t, err, ErrBadVoteFormat)
+	})
+	t.Run("Returns a bad selections error on single votes of approval sessions", func(t *testing.T) {
+		_, err := service.CreateVote("otherID", agendaID, "approval", "01791229005", "S")
+
+		assertValue(t, err, ErrBadSelections)
+	})
+}
@@ -0,0 +1,121 @@
+package vote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+// Actions recorded in the history of a vote
+const (
+	ActionChange     = "change"
+	ActionRetraction = "retraction"
+)
+
+var (
+	// ErrVoteNotFound represents an error caused by an associate without a
+	// vote in the session or by a document other than the one that voted
+	ErrVoteNotFound = errors.New("Vote not found")
+	// ErrVoteChangesNotAllowed represents an error caused by changing a vote
+	// of a session that does not allow it
+	ErrVoteChangesNotAllowed = errors.New("This voting session does not allow vote changes")
+)
+
+// Change Representation of a change made to a vote, kept for audit. The
+// vote is empty on retractions, changes of ranked and approval sessions
+// carry the previous and the new ranking or selections
+type Change struct {
+	AssociateID        string
+	SessionID          string
+	Document           string
+	Action             string
+	Previous           string
+	PreviousRanking    []string
+	PreviousSelections []string
+	Vote               string
+	Ranking            []string
+	Selections         []string
+	Creation           time.Time
+}
+
+// ChangeVote replaces the vote of an associate, the last vote is the one
+// counted. The session must allow vote changes and still be open, the new
+// choice is validated as a new vote of the session would be
+func (s *voteService) ChangeVote(id, agendaID, session, document string, c Choice) (Vote, error) {
+	sess, current, err := s.findChangeableVote(id, agendaID, session, document)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	chosen, err := s.choose(sess, c)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	now := s.clock.Now()
+	err = s.repo.UpdateVote(Change{
+		AssociateID:        id,
+		SessionID:          session,
+		Document:           current.Document,
+		Action:             ActionChange,
+		Previous:           current.Vote,
+		PreviousRanking:    current.Ranking,
+		PreviousSelections: current.Selections,
+		Vote:               chosen.Vote,
+		Ranking:            chosen.Ranking,
+		Selections:         chosen.Selections,
+		Creation:           now,
+	})
+	if err != nil {
+		return Vote{}, err
+	}
+
+	current.Vote, current.Ranking, current.Selections = chosen.Vote, chosen.Ranking, chosen.Selections
+	current.ProxyDocument, current.Creation = "", now
+	return current, nil
+}
+
+// RetractVote removes the vote of an associate, who is able to vote again
+// while the session is open. The session must allow vote changes
+func (s *voteService) RetractVote(id, agendaID, session, document string) error {
+	_, current, err := s.findChangeableVote(id, agendaID, session, document)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteVote(Change{
+		AssociateID:        id,
+		SessionID:          session,
+		Document:           current.Document,
+		Action:             ActionRetraction,
+		Previous:           current.Vote,
+		PreviousRanking:    current.Ranking,
+		PreviousSelections: current.Selections,
+		Creation:           s.clock.Now(),
+	})
+}
+
+// findChangeableVote returns the associate vote if the session allows it
+// to be changed now, the document must be the one that voted
+func (s *voteService) findChangeableVote(id, agendaID, sessionID, document string) (session.Session, Vote, error) {
+	sess, err := s.findSession(agendaID, sessionID)
+	if err != nil {
+		return session.Session{}, Vote{}, err
+	}
+	if !sess.VoteChanges {
+		return session.Session{}, Vote{}, ErrVoteChangesNotAllowed
+	}
+	if err := s.checkOpen(sess); err != nil {
+		return session.Session{}, Vote{}, err
+	}
+
+	current, err := s.repo.FindVote(sessionID, id)
+	if err != nil {
+		return session.Session{}, Vote{}, err
+	}
+	if current.Document != NormalizeDocument(document) {
+		return session.Session{}, Vote{}, ErrVoteNotFound
+	}
+	return sess, current, nil
+}
@@ -0,0 +1,219 @@
+package vote
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+func newChangeTestService(now time.Time) (*voteService, *VoteRepoStub) {
+	sStore := map[string]session.Session{
+		"changeable": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+			VoteChanges:    true,
+		},
+		"fixed": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+		},
+		"ranked": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+			VoteChanges:    true,
+			Method:         session.RankedMethod,
+		},
+		"approval": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+			VoteChanges:    true,
+			Method:         session.ApprovalMethod,
+		},
+	}
+	vStore := map[string]Vote{
+		"voter":    {AssociateID: "voter", SessionID: "changeable", Document: "01791229005", Vote: "S"},
+		"error":    {AssociateID: "error", SessionID: "changeable", Document: "01791229005", Vote: "S"},
+		"stayer":   {AssociateID: "stayer", SessionID: "fixed", Document: "01791229005", Vote: "S"},
+		"ranker":   {AssociateID: "ranker", SessionID: "ranked", Document: "01791229005", Vote: "S", Ranking: []string{"S", "N"}},
+		"approver": {AssociateID: "approver", SessionID: "approval", Document: "01791229005", Vote: "S", Selections: []string{"S"}},
+	}
+	repo := &VoteRepoStub{sStore, vStore, map[string]Vote{}, nil, nil}
+	return &voteService{repo, DocValidatorStub{}, &ClockStub{RightNow: now}, 0}, repo
+}
+
+func TestChangeVote(t *testing.T) {
+	now := time.Now()
+	t.Run("Replaces the vote and keeps the change", func(t *testing.T) {
+		service, repo := newChangeTestService(now)
+
+		got, err := service.ChangeVote("voter", "agendaID", "changeable", "017.912.290-05", Choice{Vote: "N"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "N")
+		assertValue(t, repo.voteStore["voter"].Vote, "N")
+		assertValue(t, len(repo.history), 1)
+		want := Change{
+			AssociateID: "voter",
+			SessionID:   "changeable",
+			Document:    "01791229005",
+			Action:      ActionChange,
+			Previous:    "S",
+			Vote:        "N",
+			Creation:    now,
+		}
+		if !reflect.DeepEqual(repo.history[0], want) {
+			t.Errorf("want %v, got %v", want, repo.history[0])
+		}
+	})
+	t.Run("Accepts a change to an abstention", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		got, err := service.ChangeVote("voter", "agendaID", "changeable", "01791229005", Choice{Vote: agenda.Abstention})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, agenda.Abstention)
+	})
+	t.Run("Replaces the ranking of a ranked session", func(t *testing.T) {
+		service, repo := newChangeTestService(now)
+
+		got, err := service.ChangeVote("ranker", "agendaID", "ranked", "01791229005", Choice{Ranking: []string{"N", "S"}})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "N")
+		assertValue(t, repo.voteStore["ranker"].Ranking[0], "N")
+		assertValue(t, repo.history[0].Ranking[1], "S")
+		if !reflect.DeepEqual(repo.history[0].PreviousRanking, []string{"S", "N"}) {
+			t.Errorf("want the previous ranking kept, got %v", repo.history[0].PreviousRanking)
+		}
+	})
+	t.Run("Replaces the selections of an approval session", func(t *testing.T) {
+		service, repo := newChangeTestService(now)
+
+		got, err := service.ChangeVote("approver", "agendaID", "approval", "01791229005", Choice{Selections: []string{"N", "S"}})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "S")
+		assertValue(t, len(repo.voteStore["approver"].Selections), 2)
+		if !reflect.DeepEqual(repo.history[0].PreviousSelections, []string{"S"}) {
+			t.Errorf("want the previous selections kept, got %v", repo.history[0].PreviousSelections)
+		}
+	})
+	t.Run("Validates the changes of ranked and approval sessions as new votes", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("ranker", "agendaID", "ranked", "01791229005", Choice{Vote: "N"})
+		assertValue(t, err, ErrBadRanking)
+		_, err = service.ChangeVote("ranker", "agendaID", "ranked", "01791229005", Choice{Ranking: []string{"N"}})
+		assertValue(t, err, ErrBadRanking)
+		_, err = service.ChangeVote("approver", "agendaID", "approval", "01791229005", Choice{Vote: "N"})
+		assertValue(t, err, ErrBadSelections)
+		_, err = service.ChangeVote("voter", "agendaID", "changeable", "01791229005", Choice{Ranking: []string{"N", "S"}})
+		assertValue(t, err, ErrBadVoteFormat)
+	})
+	t.Run("Returns a Vote Changes Not Allowed error if the session does not allow it", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("stayer", "agendaID", "fixed", "01791229005", Choice{Vote: "N"})
+
+		assertValue(t, err, ErrVoteChangesNotAllowed)
+	})
+	t.Run("Returns a Session Expired error after the expiration", func(t *testing.T) {
+		service, _ := newChangeTestService(now.Add(-2 * time.Hour))
+		service.clock = &ClockStub{RightNow: now}
+
+		_, err := service.ChangeVote("voter", "agendaID", "changeable", "01791229005", Choice{Vote: "N"})
+
+		assertValue(t, err, ErrSessionExpired)
+	})
+	t.Run("Returns a Vote Not Found error if the document did not vote", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("voter", "agendaID", "changeable", "52998224725", Choice{Vote: "N"})
+
+		assertValue(t, err, ErrVoteNotFound)
+	})
+	t.Run("Returns a Vote Not Found error if the associate did not vote", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("absent", "agendaID", "changeable", "01791229005", Choice{Vote: "N"})
+
+		assertValue(t, err, ErrVoteNotFound)
+	})
+	t.Run("Returns a Session Not Found error if it belongs to another agenda", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("voter", "otherAgenda", "changeable", "01791229005", Choice{Vote: "N"})
+
+		assertValue(t, err, ErrSessionNotFound)
+	})
+	t.Run("Returns an Bad Format error if its not valid vote", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("voter", "agendaID", "changeable", "01791229005", Choice{Vote: "X"})
+
+		assertValue(t, err, ErrBadVoteFormat)
+	})
+	t.Run("Returns the error if there was any error", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		_, err := service.ChangeVote("error", "agendaID", "changeable", "01791229005", Choice{Vote: "N"})
+
+		assertValue(t, err.Error(), "ops, there was an error")
+	})
+}
+
+func TestRetractVote(t *testing.T) {
+	now := time.Now()
+	t.Run("Removes the vote and keeps the retraction", func(t *testing.T) {
+		service, repo := newChangeTestService(now)
+
+		err := service.RetractVote("voter", "agendaID", "changeable", "01791229005")
+
+		assertValue(t, err, nil)
+		if _, ok := repo.voteStore["voter"]; ok {
+			t.Errorf("the vote was not removed")
+		}
+		assertValue(t, repo.history[0].Action, ActionRetraction)
+		assertValue(t, repo.history[0].Previous, "S")
+		assertValue(t, repo.history[0].Vote, "")
+	})
+	t.Run("Keeps the ranking of a retracted ranked vote", func(t *testing.T) {
+		service, repo := newChangeTestService(now)
+
+		err := service.RetractVote("ranker", "agendaID", "ranked", "01791229005")
+
+		assertValue(t, err, nil)
+		if !reflect.DeepEqual(repo.history[0].PreviousRanking, []string{"S", "N"}) {
+			t.Errorf("want the previous ranking kept, got %v", repo.history[0].PreviousRanking)
+		}
+	})
+	t.Run("Lets the associate vote again", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+		service.RetractVote("voter", "agendaID", "changeable", "01791229005")
+
+		_, err := service.CreateVote("voter", "agendaID", "changeable", "01791229005", "N")
+
+		assertValue(t, err, nil)
+	})
+	t.Run("Returns a Vote Changes Not Allowed error if the session does not allow it", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		err := service.RetractVote("stayer", "agendaID", "fixed", "01791229005")
+
+		assertValue(t, err, ErrVoteChangesNotAllowed)
+	})
+	t.Run("Returns a Vote Not Found error if the document did not vote", func(t *testing.T) {
+		service, _ := newChangeTestService(now)
+
+		err := service.RetractVote("voter", "agendaID", "changeable", "52998224725")
+
+		assertValue(t, err, ErrVoteNotFound)
+	})
+}
@@ -0,0 +1,141 @@
+package vote
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes a delegation may apply to
+const (
+	ScopeSession = "session"
+	ScopeAgenda  = "agenda"
+)
+
+var (
+	// ErrDelegationNotFound represents an error caused by an unknown delegation
+	ErrDelegationNotFound = errors.New("Delegation not found")
+	// ErrBadDelegation represents an error caused by a delegation without a
+	// grantor, to the grantor itself or outside an agenda
+	ErrBadDelegation = errors.New("Delegations must have a grantor, a different proxy and an agenda, the session is optional")
+	// ErrDuplicateDelegation represents an error caused by a grantor
+	// delegating twice a vote in the same scope
+	ErrDuplicateDelegation = errors.New("The grantor has already delegated this vote")
+	// ErrTooManyProxies represents an error caused by a proxy holding the
+	// maximum number of delegations of the agenda
+	ErrTooManyProxies = errors.New("The proxy already holds the maximum number of delegations of this agenda")
+	// ErrNotAProxy represents an error caused by a vote cast on behalf of a
+	// grantor that did not delegate it to the voter
+	ErrNotAProxy = errors.New("The grantor did not delegate this vote to the voter")
+)
+
+// Delegation Representation of the vote of a grantor delegated to a proxy,
+// for a single session or for every session of an agenda. Grantors are
+// identified as voters, proxies by document
+type Delegation struct {
+	ID              string
+	GrantorID       string
+	GrantorDocument string
+	ProxyDocument   string
+	Scope           string
+	AgendaID        string
+	SessionID       string
+	Creation        time.Time
+}
+
+// DelegationParams Set of parameters describing a delegation, it applies
+// to the whole agenda when no session is informed
+type DelegationParams struct {
+	GrantorID       string
+	GrantorDocument string
+	ProxyDocument   string
+	AgendaID        string
+	SessionID       string
+}
+
+// appliesTo reports whether the delegation covers the session
+func (d *Delegation) appliesTo(sessionID string) bool {
+	return d.Scope == ScopeAgenda || d.SessionID == sessionID
+}
+
+// CreateDelegation stores a new delegation. A grantor delegates each vote
+// once and a proxy holds up to the configured number of delegations of
+// an agenda, zero for no limit. Both are checked by the repository as the
+// delegation is stored, so concurrent delegations cannot exceed them
+func (s *voteService) CreateDelegation(p DelegationParams) (Delegation, error) {
+	grantor, proxy := NormalizeDocument(p.GrantorDocument), NormalizeDocument(p.ProxyDocument)
+	if p.GrantorID == "" || p.AgendaID == "" || grantor == proxy {
+		return Delegation{}, ErrBadDelegation
+	}
+	if !ValidDocument(grantor) || !ValidDocument(proxy) {
+		return Delegation{}, ErrInvalidDocument
+	}
+
+	d := Delegation{
+		ID:              uuid.New().String(),
+		GrantorID:       p.GrantorID,
+		GrantorDocument: grantor,
+		ProxyDocument:   proxy,
+		Scope:           ScopeAgenda,
+		AgendaID:        p.AgendaID,
+		Creation:        s.clock.Now(),
+	}
+	if p.SessionID != "" {
+		sess, err := s.findSession(p.AgendaID, p.SessionID)
+		if err != nil {
+			return Delegation{}, err
+		}
+		if err := s.checkOpen(sess); err != nil && err != ErrSessionNotOpen {
+			return Delegation{}, err
+		}
+		d.Scope, d.SessionID = ScopeSession, p.SessionID
+	} else if _, err := s.repo.FindAgenda(p.AgendaID); err != nil {
+		return Delegation{}, err
+	}
+
+	if err := s.repo.InsertDelegation(d, s.maxProxies); err != nil {
+		return Delegation{}, err
+	}
+	return d, nil
+}
+
+// FindDelegation returns a delegation finding by ID
+func (s *voteService) FindDelegation(id string) (Delegation, error) {
+	return s.repo.FindDelegation(id)
+}
+
+// CreateProxyVote casts the vote of a grantor through its proxy. The vote
+// counts as the grantor own vote, so it is cast once, either by the
+// grantor or by the proxy, and carries the grantor weight. Like any vote
+// it is an option, a ranking or a set of selections as the session takes
+func (s *voteService) CreateProxyVote(proxyDocument, grantorID, agendaID, session string, c Choice) (Vote, error) {
+	sess, err := s.findSession(agendaID, session)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	v, err := s.choose(sess, c)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	delegated, err := s.repo.FindGrantorDelegations(grantorID, agendaID)
+	if err != nil {
+		return Vote{}, err
+	}
+	var d *Delegation
+	for i := range delegated {
+		if delegated[i].appliesTo(session) && delegated[i].ProxyDocument == NormalizeDocument(proxyDocument) {
+			d = &delegated[i]
+			break
+		}
+	}
+	if d == nil {
+		return Vote{}, ErrNotAProxy
+	}
+
+	v.AssociateID, v.SessionID = grantorID, session
+	v.Document, v.ProxyDocument = d.GrantorDocument, d.ProxyDocument
+	return s.cast(sess, v)
+}
@@ -0,0 +1,221 @@
+package vote
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+const (
+	grantorDocument = "39053344705"
+	proxyDocument   = "52998224725"
+)
+
+func newDelegationTestService(now time.Time) (*voteService, *VoteRepoStub) {
+	sStore := map[string]session.Session{
+		"open": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+		},
+		"other": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+		},
+		"secret": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+			Secret:         true,
+		},
+		"ranked": {
+			OriginalAgenda: "agendaID",
+			Creation:       now,
+			Duration:       time.Hour,
+			Method:         session.RankedMethod,
+		},
+		"expired": {
+			OriginalAgenda: "agendaID",
+			Creation:       now.Add(-2 * time.Hour),
+			Duration:       time.Hour,
+		},
+	}
+	repo := &VoteRepoStub{sStore, map[string]Vote{}, map[string]Vote{}, nil, nil}
+	return &voteService{repo, DocValidatorStub{}, &ClockStub{RightNow: now}, 2}, repo
+}
+
+func TestCreateDelegation(t *testing.T) {
+	now := time.Now()
+	t.Run("Stores a delegation for a single session", func(t *testing.T) {
+		service, repo := newDelegationTestService(now)
+
+		got, err := service.CreateDelegation(DelegationParams{
+			GrantorID:       "grantor",
+			GrantorDocument: "390.533.447-05",
+			ProxyDocument:   proxyDocument,
+			AgendaID:        "agendaID",
+			SessionID:       "open",
+		})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Scope, ScopeSession)
+		assertValue(t, got.GrantorDocument, grantorDocument)
+		assertValue(t, got.Creation, now)
+		assertValue(t, len(repo.delegations), 1)
+	})
+	t.Run("Stores a delegation for the whole agenda", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+
+		got, err := service.CreateDelegation(DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID",
+		})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Scope, ScopeAgenda)
+		assertValue(t, got.SessionID, "")
+	})
+	t.Run("Returns a bad delegation error if the grantor is its own proxy", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+
+		_, err := service.CreateDelegation(DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: "390.533.447-05", AgendaID: "agendaID",
+		})
+
+		assertValue(t, err, ErrBadDelegation)
+	})
+	t.Run("Returns an invalid document error if a document is malformed", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+
+		_, err := service.CreateDelegation(DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: "12345678900", AgendaID: "agendaID",
+		})
+
+		assertValue(t, err, ErrInvalidDocument)
+	})
+	t.Run("Returns a session expired error for closed sessions", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+
+		_, err := service.CreateDelegation(DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID", SessionID: "expired",
+		})
+
+		assertValue(t, err, ErrSessionExpired)
+	})
+	t.Run("Returns a duplicate delegation error for overlapping scopes", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		p := DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID", SessionID: "open",
+		}
+		service.CreateDelegation(p)
+
+		_, sameSession := service.CreateDelegation(p)
+		p.SessionID = "other"
+		_, otherSession := service.CreateDelegation(p)
+		p.SessionID = ""
+		_, wholeAgenda := service.CreateDelegation(p)
+
+		assertValue(t, sameSession, ErrDuplicateDelegation)
+		assertValue(t, otherSession, nil)
+		assertValue(t, wholeAgenda, ErrDuplicateDelegation)
+	})
+	t.Run("Returns a too many proxies error above the limit", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		for _, grantor := range []string{"first", "second", "third"} {
+			_, err := service.CreateDelegation(DelegationParams{
+				GrantorID: grantor, GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID",
+			})
+			if grantor == "third" {
+				assertValue(t, err, ErrTooManyProxies)
+			}
+		}
+	})
+	t.Run("Does not limit the proxies if the limit is zero", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		service.maxProxies = 0
+		for _, grantor := range []string{"first", "second", "third"} {
+			_, err := service.CreateDelegation(DelegationParams{
+				GrantorID: grantor, GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID",
+			})
+			assertValue(t, err, nil)
+		}
+	})
+}
+
+func TestCreateProxyVote(t *testing.T) {
+	now := time.Now()
+	delegate := func(service *voteService, sessionID string) {
+		service.CreateDelegation(DelegationParams{
+			GrantorID: "grantor", GrantorDocument: grantorDocument, ProxyDocument: proxyDocument, AgendaID: "agendaID", SessionID: sessionID,
+		})
+	}
+	t.Run("Casts the grantor vote keeping the proxy", func(t *testing.T) {
+		service, repo := newDelegationTestService(now)
+		delegate(service, "open")
+
+		got, err := service.CreateProxyVote("529.982.247-25", "grantor", "agendaID", "open", Choice{Vote: "S"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.AssociateID, "grantor")
+		assertValue(t, got.Document, grantorDocument)
+		assertValue(t, got.ProxyDocument, proxyDocument)
+		assertValue(t, got.Weight, 1)
+		assertValue(t, repo.voteStore["grantor"].ProxyDocument, proxyDocument)
+	})
+	t.Run("Casts the vote once, by the proxy or by the grantor", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		delegate(service, "")
+
+		_, err := service.CreateVote("grantor", "agendaID", "open", grantorDocument, "N")
+		assertValue(t, err, nil)
+
+		_, err = service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "open", Choice{Vote: "S"})
+		assertValue(t, err, ErrDuplicateVote)
+	})
+	t.Run("Casts the grantor ranking in ranked sessions", func(t *testing.T) {
+		service, repo := newDelegationTestService(now)
+		delegate(service, "")
+
+		got, err := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "ranked", Choice{Ranking: []string{"N", "S"}})
+		_, plain := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "ranked", Choice{Vote: "S"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "N")
+		if !reflect.DeepEqual(repo.voteStore["grantor"].Ranking, []string{"N", "S"}) {
+			t.Errorf("got %v stored", repo.voteStore["grantor"].Ranking)
+		}
+		assertValue(t, plain, ErrBadRanking)
+	})
+	t.Run("Agenda delegations apply to every session", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		delegate(service, "")
+
+		_, open := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "open", Choice{Vote: "S"})
+		_, secret := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "secret", Choice{Vote: "S"})
+
+		assertValue(t, open, nil)
+		assertValue(t, secret, nil)
+	})
+	t.Run("Returns a not a proxy error without a delegation to the voter", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		delegate(service, "open")
+
+		_, otherSession := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "other", Choice{Vote: "S"})
+		_, otherProxy := service.CreateProxyVote("11222333000181", "grantor", "agendaID", "open", Choice{Vote: "S"})
+		_, otherGrantor := service.CreateProxyVote(proxyDocument, "someone", "agendaID", "open", Choice{Vote: "S"})
+
+		assertValue(t, otherSession, ErrNotAProxy)
+		assertValue(t, otherProxy, ErrNotAProxy)
+		assertValue(t, otherGrantor, ErrNotAProxy)
+	})
+	t.Run("Returns a session expired error for closed sessions", func(t *testing.T) {
+		service, _ := newDelegationTestService(now)
+		delegate(service, "")
+
+		_, err := service.CreateProxyVote(proxyDocument, "grantor", "agendaID", "expired", Choice{Vote: "S"})
+
+		assertValue(t, err, ErrSessionExpired)
+	})
+}
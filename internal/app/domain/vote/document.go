@@ -0,0 +1,95 @@
+package vote
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidDocument represents an error caused by a document failing the
+// CPF or CNPJ check digits
+var ErrInvalidDocument = errors.New("Invalid document. Must be a valid CPF or CNPJ")
+
+var documentPunctuation = strings.NewReplacer(".", "", "-", "", "/", "", " ", "")
+
+// NormalizeDocument strips the punctuation of a formatted CPF or CNPJ,
+// like 123.456.789-09 or 12.345.678/0001-95
+func NormalizeDocument(document string) string {
+	return documentPunctuation.Replace(document)
+}
+
+// ValidDocument reports whether the normalized document is a CPF or a
+// CNPJ with valid check digits
+func ValidDocument(document string) bool {
+	for _, r := range document {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	switch len(document) {
+	case 11:
+		return validCheckDigits(document, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}, cpfDigit)
+	case 14:
+		return validCheckDigits(document, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, cnpjDigit)
+	default:
+		return false
+	}
+}
+
+// validCheckDigits verifies the two trailing check digits, the second one
+// is computed over the first with the weights shifted by one position
+func validCheckDigits(document string, weights []int, digit func(sum int) int) bool {
+	if strings.Count(document, document[:1]) == len(document) {
+		return false
+	}
+
+	n := len(weights)
+	first := digit(weightedSum(document[:n], weights))
+	second := digit(weightedSum(document[:n+1], append([]int{weights[0] + 1}, weights...)))
+	return int(document[n]-'0') == first && int(document[n+1]-'0') == second
+}
+
+func weightedSum(digits string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	return sum
+}
+
+func cpfDigit(sum int) int {
+	d := sum * 10 % 11
+	if d == 10 {
+		return 0
+	}
+	return d
+}
+
+func cnpjDigit(sum int) int {
+	r := sum % 11
+	if r < 2 {
+		return 0
+	}
+	return 11 - r
+}
+
+// NewChecksumValidator returns a DocValidator that refuses documents with
+// invalid check digits before asking the next validator, sparing it the
+// malformed documents
+func NewChecksumValidator(next DocValidator) DocValidator {
+	return &checksumValidator{next: next}
+}
+
+type checksumValidator struct {
+	next DocValidator
+}
+
+// ValidateDocument returns ErrInvalidDocument for malformed documents and
+// the answer of the next validator otherwise
+func (v *checksumValidator) ValidateDocument(document string) (bool, error) {
+	normalized := NormalizeDocument(document)
+	if !ValidDocument(normalized) {
+		return false, ErrInvalidDocument
+	}
+	return v.next.ValidateDocument(normalized)
+}
@@ -0,0 +1,68 @@
+package vote
+
+import (
+	"errors"
+	"testing"
+)
+
+type recordingValidator struct {
+	CalledWith []string
+}
+
+func (v *recordingValidator) ValidateDocument(doc string) (bool, error) {
+	v.CalledWith = append(v.CalledWith, doc)
+	return true, nil
+}
+
+func TestValidDocument(t *testing.T) {
+	cases := []struct {
+		name     string
+		document string
+		want     bool
+	}{
+		{"a valid CPF", "52998224725", true},
+		{"a CPF with a wrong first check digit", "52998224715", false},
+		{"a CPF with a wrong second check digit", "52998224726", false},
+		{"a CPF with repeated digits", "11111111111", false},
+		{"a valid CNPJ", "11222333000181", true},
+		{"a CNPJ with a wrong check digit", "11222333000182", false},
+		{"a CNPJ with repeated digits", "00000000000000", false},
+		{"a document with letters", "5299822472a", false},
+		{"a document with the wrong length", "5299822472", false},
+		{"an empty document", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assertValue(t, ValidDocument(c.document), c.want)
+		})
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	t.Run("strips CPF punctuation", func(t *testing.T) {
+		assertValue(t, NormalizeDocument("529.982.247-25"), "52998224725")
+	})
+	t.Run("strips CNPJ punctuation", func(t *testing.T) {
+		assertValue(t, NormalizeDocument("11.222.333/0001-81"), "11222333000181")
+	})
+}
+
+func TestChecksumValidator(t *testing.T) {
+	next := recordingValidator{}
+	validator := NewChecksumValidator(&next)
+	t.Run("asks the next validator with the normalized document", func(t *testing.T) {
+		able, err := validator.ValidateDocument("529.982.247-25")
+
+		assertValue(t, err, nil)
+		assertValue(t, able, true)
+		assertValue(t, next.CalledWith[len(next.CalledWith)-1], "52998224725")
+	})
+	t.Run("refuses invalid documents without asking the next validator", func(t *testing.T) {
+		calls := len(next.CalledWith)
+
+		_, err := validator.ValidateDocument("529.982.247-00")
+
+		assertValue(t, errors.Is(err, ErrInvalidDocument), true)
+		assertValue(t, len(next.CalledWith), calls)
+	})
+}
@@ -2,80 +2,206 @@ package vote
 
 import (
 	"errors"
-	"strings"
-	"time"
+	"fmt"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/clock"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
 
-// NewVoteService creates and returns an agenda service
-func NewVoteService(r Repository, v DocValidator) Service {
+// NewVoteService creates and returns an agenda service, a proxy holds up
+// to maxProxies delegations of an agenda, zero for no limit
+func NewVoteService(r Repository, v DocValidator, maxProxies int) Service {
 	return &voteService{
-		repo:      r,
-		validator: v,
-		clock:     &internalClock{},
+		repo:       r,
+		validator:  v,
+		clock:      clock.System{},
+		maxProxies: maxProxies,
 	}
 }
 
-type internalClock struct{}
-
-func (c *internalClock) Now() time.Time {
-	return time.Now()
-}
-
-type clock interface {
-	Now() time.Time
-}
-
 type voteService struct {
-	repo      Repository
-	validator DocValidator
-	clock     clock
+	repo       Repository
+	validator  DocValidator
+	clock      clock.Clock
+	maxProxies int
 }
 
 var (
 	// ErrDuplicateVote represents an error caused a voting duplication
 	ErrDuplicateVote = errors.New("Duplicate vote")
-	// ErrBadVoteFormat represents an error caused a voting duplication
-	ErrBadVoteFormat = errors.New("Bad formating in vote. Must be 'S' or 'N'")
+	// ErrBadVoteFormat represents an error caused by a vote outside the agenda options
+	ErrBadVoteFormat = errors.New("Bad formating in vote. Must be one of the agenda options")
 	// ErrSessionExpired represents an error caused by session expiration
 	ErrSessionExpired = errors.New("This voting session is expired")
+	// ErrSessionNotOpen represents an error caused by voting in a session
+	// scheduled to open later
+	ErrSessionNotOpen = errors.New("This voting session is not open yet")
+	// ErrSessionCancelled represents an error caused by voting in a
+	// cancelled session
+	ErrSessionCancelled = errors.New("This voting session was cancelled")
+	// ErrSessionNotFound represents an error caused by a session that does
+	// not belong to the informed agenda
+	ErrSessionNotFound = session.ErrSessionNotFound
 	// ErrNotAbleToVote represents an error caused by invalid document
 	ErrNotAbleToVote = errors.New("Associate not able to vote")
+	// ErrNotInRoster represents an error caused by a voter outside the roster
+	// the session is restricted to
+	ErrNotInRoster = errors.New("Associate not in the session roster")
+	// ErrValidatorUnavailable represents an error caused by the document
+	// validator not answering
+	ErrValidatorUnavailable = errors.New("Document validation is unavailable, try again later")
 )
 
-// CreateVote creates an vote and stores it
-func (s *voteService) CreateVote(id, session, document, vote string) (Vote, error) {
-	if len(vote) > 1 || !strings.Contains("SN", vote) {
-		return Vote{}, ErrBadVoteFormat
-	}
-
-	isValidDoc, err := s.validator.ValidateDocument(document)
+// CreateVote creates an vote and stores it, the session must belong to
+// the informed agenda and, if restricted to a roster, the document must
+// be listed in it. The vote carries the weight of the voter, taken from
+// the roster or from the associate registry. Votes of secret sessions are
+// stored apart from their voter
+func (s *voteService) CreateVote(id, agendaID, session, document, vote string) (Vote, error) {
+	sess, err := s.findSession(agendaID, session)
 	if err != nil {
-		return Vote{}, ErrSessionExpired
+		return Vote{}, err
 	}
-	if !isValidDoc {
-		return Vote{}, ErrNotAbleToVote
+
+	if err := s.checkOption(sess, vote); err != nil {
+		return Vote{}, err
 	}
 
-	v := Vote{
+	return s.cast(sess, Vote{
 		AssociateID: id,
 		SessionID:   session,
-		Document:    document,
+		Document:    NormalizeDocument(document),
 		Vote:        vote,
-		Creation:    time.Now(),
+	})
+}
+
+// cast checks the session is open, then checks the voter, weighs and
+// stores the vote. Closed sessions never reach the validator
+func (s *voteService) cast(sess session.Session, v Vote) (Vote, error) {
+	if err := s.checkOpen(sess); err != nil {
+		return Vote{}, err
 	}
 
-	sess, err := s.repo.FindSession(v.SessionID)
+	if err := s.checkVoter(sess, v.Document); err != nil {
+		return Vote{}, err
+	}
+
+	weight, err := s.repo.FindVoterWeight(sess.RosterID, v.Document)
 	if err != nil {
 		return Vote{}, err
 	}
+	v.Weight, v.Creation = weight, s.clock.Now()
+
+	if err := s.insert(sess, v); err != nil {
+		return Vote{}, err
+	}
+	return v, nil
+}
+
+// checkOpen returns an error unless the session is open, ErrSessionNotOpen
+// while it is scheduled and ErrSessionExpired once it closed
+func (s *voteService) checkOpen(sess session.Session) error {
+	switch sess.StateAt(s.clock.Now()) {
+	case session.StateOpen:
+		return nil
+	case session.StateScheduled:
+		return ErrSessionNotOpen
+	case session.StateCancelled:
+		return ErrSessionCancelled
+	default:
+		return ErrSessionExpired
+	}
+}
 
-	if s.clock.Now().After(sess.GetExpiration()) {
-		return Vote{}, ErrSessionExpired
+// checkVoter checks, if the session is restricted to a roster, that the
+// document is listed in it and then validates the document. Non members
+// are refused before reaching the validator
+func (s *voteService) checkVoter(sess session.Session, document string) error {
+	if sess.RosterID != "" {
+		member, err := s.repo.IsRosterMember(sess.RosterID, NormalizeDocument(document))
+		if err != nil {
+			return err
+		}
+		if !member {
+			return ErrNotInRoster
+		}
 	}
 
-	err = s.repo.InsertVote(v)
+	isValidDoc, err := s.validator.ValidateDocument(document)
 	if err != nil {
+		if errors.Is(err, ErrValidatorUnavailable) || errors.Is(err, ErrInvalidDocument) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrValidatorUnavailable, err)
+	}
+	if !isValidDoc {
+		return ErrNotAbleToVote
+	}
+	return nil
+}
+
+// insert stores the vote, apart from its voter in secret sessions
+func (s *voteService) insert(sess session.Session, v Vote) error {
+	if sess.Secret {
+		return s.repo.InsertSecretVote(v)
+	}
+	return s.repo.InsertVote(v)
+}
+
+// findSession returns the session if it belongs to the informed agenda
+func (s *voteService) findSession(agendaID, id string) (session.Session, error) {
+	sess, err := s.repo.FindSession(id)
+	if err != nil {
+		return session.Session{}, err
+	}
+	if sess.OriginalAgenda != agendaID {
+		return session.Session{}, ErrSessionNotFound
+	}
+	return sess, nil
+}
+
+// choose validates the choice for the session and returns the vote it
+// stands for, the vote of rankings and selections being their first
+// option. Abstentions are taken by every method
+func (s *voteService) choose(sess session.Session, c Choice) (Vote, error) {
+	switch {
+	case len(c.Ranking) > 0:
+		if err := s.checkRanking(sess, c.Ranking); err != nil {
+			return Vote{}, err
+		}
+		return Vote{Vote: c.Ranking[0], Ranking: c.Ranking}, nil
+	case len(c.Selections) > 0:
+		approved, err := s.checkSelections(sess, c.Selections)
+		if err != nil {
+			return Vote{}, err
+		}
+		return Vote{Vote: approved[0], Selections: approved}, nil
+	}
+
+	if err := s.checkOption(sess, c.Vote); err != nil {
 		return Vote{}, err
 	}
-	return v, nil
+	return Vote{Vote: c.Vote}, nil
+}
+
+// checkOption returns ErrBadVoteFormat if the vote is neither one of the
+// agenda options nor an abstention. Ranked and approval sessions only
+// take abstentions outside a ranking or a set of selections
+func (s *voteService) checkOption(sess session.Session, vote string) error {
+	if sess.Method == session.RankedMethod && vote != agenda.Abstention {
+		return ErrBadRanking
+	}
+	if sess.Method == session.ApprovalMethod && vote != agenda.Abstention {
+		return ErrBadSelections
+	}
+
+	a, err := s.repo.FindAgenda(sess.OriginalAgenda)
+	if err != nil {
+		return err
+	}
+	if vote != agenda.Abstention && !a.HasOption(vote) {
+		return ErrBadVoteFormat
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
 
@@ -21,19 +22,89 @@ func (c ClockStub) Now() time.Time {
 type VoteRepoStub struct {
 	sessionStore map[string]session.Session
 	voteStore    map[string]Vote
+	secretStore  map[string]Vote
+	history      []Change
+	delegations  []Delegation
 }
 
 func (r *VoteRepoStub) FindSession(ID string) (session.Session, error) {
 	s, ok := r.sessionStore[ID]
 	if ok == false {
-		return session.Session{}, errors.New("Session not found")
+		return session.Session{}, session.ErrSessionNotFound
 	}
 	return s, nil
 }
 
+func (r *VoteRepoStub) FindAgenda(ID string) (agenda.Agenda, error) {
+	return agenda.Agenda{
+		ID:      ID,
+		Options: agenda.DefaultOptions,
+	}, nil
+}
+
+func (r *VoteRepoStub) IsRosterMember(rosterID, document string) (bool, error) {
+	if rosterID == "error" {
+		return false, errors.New("ops, there was an error")
+	}
+	return document == "01791229005", nil
+}
+
+func (r *VoteRepoStub) FindVoterWeight(rosterID, document string) (int, error) {
+	if rosterID != "" {
+		return 3, nil
+	}
+	return 1, nil
+}
+
+func (r *VoteRepoStub) InsertDelegation(d Delegation, maxProxies int) error {
+	if d.GrantorID == "error" {
+		return errors.New("ops, there was an error")
+	}
+	held := 0
+	for _, other := range r.delegations {
+		if other.GrantorID == d.GrantorID && other.AgendaID == d.AgendaID &&
+			(d.Scope == ScopeAgenda || other.appliesTo(d.SessionID)) {
+			return ErrDuplicateDelegation
+		}
+		if other.ProxyDocument == d.ProxyDocument && other.AgendaID == d.AgendaID {
+			held++
+		}
+	}
+	if maxProxies > 0 && held >= maxProxies {
+		return ErrTooManyProxies
+	}
+	r.delegations = append(r.delegations, d)
+	return nil
+}
+
+func (r *VoteRepoStub) FindDelegation(id string) (Delegation, error) {
+	for _, d := range r.delegations {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return Delegation{}, ErrDelegationNotFound
+}
+
+func (r *VoteRepoStub) FindGrantorDelegations(grantorID, agendaID string) ([]Delegation, error) {
+	var found []Delegation
+	for _, d := range r.delegations {
+		if d.GrantorID == grantorID && d.AgendaID == agendaID {
+			found = append(found, d)
+		}
+	}
+	return found, nil
+}
+
 type DocValidatorStub struct{}
 
 func (v DocValidatorStub) ValidateDocument(doc string) (bool, error) {
+	if doc == "unavailable" {
+		return false, errors.New("connection refused")
+	}
+	if doc == "invalid" {
+		return false, ErrInvalidDocument
+	}
 	return !strings.Contains(doc, "error"), nil
 }
 
@@ -48,26 +119,61 @@ func (r *VoteRepoStub) InsertVote(v Vote) error {
 	return nil
 }
 
+func (r *VoteRepoStub) InsertSecretVote(v Vote) error {
+	if _, ok := r.secretStore[v.AssociateID]; ok == true {
+		return ErrDuplicateVote
+	}
+	r.secretStore[v.AssociateID] = v
+	return nil
+}
+
+func (r *VoteRepoStub) FindVote(sessionID, associateID string) (Vote, error) {
+	v, ok := r.voteStore[associateID]
+	if !ok || v.SessionID != sessionID {
+		return Vote{}, ErrVoteNotFound
+	}
+	return v, nil
+}
+
+func (r *VoteRepoStub) UpdateVote(c Change) error {
+	if c.AssociateID == "error" {
+		return errors.New("ops, there was an error")
+	}
+	v := r.voteStore[c.AssociateID]
+	v.Vote, v.Ranking, v.Selections = c.Vote, c.Ranking, c.Selections
+	r.voteStore[c.AssociateID] = v
+	r.history = append(r.history, c)
+	return nil
+}
+
+func (r *VoteRepoStub) DeleteVote(c Change) error {
+	delete(r.voteStore, c.AssociateID)
+	r.history = append(r.history, c)
+	return nil
+}
+
 func TestCreateVote(t *testing.T) {
+	agendaID := "agendaID"
 	sStore := map[string]session.Session{
 		"sessionID": {
-			Creation: time.Now(),
-			Duration: time.Hour,
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
 		},
 	}
 	vStore := map[string]Vote{
 		"existing": {},
 	}
-	repo := VoteRepoStub{sStore, vStore}
+	repo := VoteRepoStub{sStore, vStore, map[string]Vote{}, nil, nil}
 	now := time.Now()
 	clockStub := ClockStub{RightNow: now}
-	service := voteService{&repo, DocValidatorStub{}, &clockStub}
+	service := voteService{&repo, DocValidatorStub{}, &clockStub, 0}
 	t.Run("Returns an vote", func(t *testing.T) {
 		associateID := "anID"
 		sessionID := "sessionID"
 		document := "01791229005"
 		vote := "S"
-		got, _ := service.CreateVote(associateID, sessionID, document, vote)
+		got, _ := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := Vote{}
 
 		assertType(t, got, want)
@@ -76,34 +182,53 @@ func TestCreateVote(t *testing.T) {
 		assertValue(t, got.SessionID, sessionID)
 		assertValue(t, got.Document, document)
 		assertValue(t, got.Vote, vote)
+		assertValue(t, got.Weight, 1)
+	})
+	t.Run("Accepts an abstention", func(t *testing.T) {
+		associateID := "abstainingID"
+		sessionID := "sessionID"
+		document := "01791229005"
+		got, err := service.CreateVote(associateID, agendaID, sessionID, document, agenda.Abstention)
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, agenda.Abstention)
 	})
 	t.Run("Returns an Session not found error if it does not exists", func(t *testing.T) {
 		associateID := "anID"
 		sessionID := "notFound"
 		document := "01791229005"
 		vote := "S"
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := errors.New("Session not found")
 
 		assertValue(t, got.Error(), want.Error())
 	})
+	t.Run("Returns an Session not found error if it belongs to another agenda", func(t *testing.T) {
+		associateID := "anID"
+		sessionID := "sessionID"
+		document := "01791229005"
+		vote := "S"
+		_, got := service.CreateVote(associateID, "otherAgendaID", sessionID, document, vote)
+
+		assertValue(t, got, ErrSessionNotFound)
+	})
 	t.Run("Returns an Duplicate Vote error if its duplicate", func(t *testing.T) {
 		associateID := "existing"
 		sessionID := "sessionID"
 		document := "01791229005"
 		vote := "S"
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := ErrDuplicateVote
 
 		assertValue(t, got.Error(), want.Error())
 	})
 	t.Run("Returns an Bad Format error if its not valid vote", func(t *testing.T) {
-		associateID := "existing"
+		associateID := "anotherID"
 		sessionID := "sessionID"
 		document := "01791229005"
-		vote := "S"
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
-		want := ErrDuplicateVote
+		vote := "X"
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
+		want := ErrBadVoteFormat
 
 		assertValue(t, got.Error(), want.Error())
 	})
@@ -114,11 +239,50 @@ func TestCreateVote(t *testing.T) {
 		vote := "S"
 
 		clockStub.RightNow = time.Now().Add(2 * time.Hour)
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := ErrSessionExpired
 
 		assertValue(t, got.Error(), want.Error())
 	})
+	t.Run("Returns a Session Not Open error if the session is scheduled", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		sStore["scheduledSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Start:          time.Now().Add(time.Hour),
+			Duration:       time.Hour,
+		}
+
+		_, got := service.CreateVote("scheduledID", agendaID, "scheduledSession", "01791229005", "S")
+
+		assertValue(t, got, ErrSessionNotOpen)
+	})
+	t.Run("Returns an Session Expired error if the session was closed", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		sStore["closedSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			State:          session.StateClosed,
+		}
+
+		_, got := service.CreateVote("closedID", agendaID, "closedSession", "01791229005", "S")
+
+		assertValue(t, got, ErrSessionExpired)
+	})
+	t.Run("Returns a Session Cancelled error if the session was cancelled", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		sStore["cancelledSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			State:          session.StateCancelled,
+		}
+
+		_, got := service.CreateVote("cancelledID", agendaID, "cancelledSession", "01791229005", "S")
+
+		assertValue(t, got, ErrSessionCancelled)
+	})
 	t.Run("Returns an Not Able to Vote error if the document isn't valid", func(t *testing.T) {
 		clockStub.RightNow = time.Now()
 		associateID := "thisIsAnID"
@@ -126,17 +290,105 @@ func TestCreateVote(t *testing.T) {
 		document := "error"
 		vote := "S"
 
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := ErrNotAbleToVote
 
 		assertValue(t, got.Error(), want.Error())
 	})
+	t.Run("Returns an Invalid Document error if the document is malformed", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		_, got := service.CreateVote("thisIsAnID", agendaID, "sessionID", "invalid", "S")
+
+		assertValue(t, got, ErrInvalidDocument)
+	})
+	t.Run("Stores the document without punctuation", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		got, _ := service.CreateVote("formattedID", agendaID, "sessionID", "529.982.247-25", "S")
+
+		assertValue(t, got.Document, "52998224725")
+	})
+	t.Run("Returns a Validator Unavailable error if the validator fails", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		_, got := service.CreateVote("thisIsAnID", agendaID, "sessionID", "unavailable", "S")
+
+		assertValue(t, errors.Is(got, ErrValidatorUnavailable), true)
+	})
+	t.Run("Refuses closed sessions before checking the voter", func(t *testing.T) {
+		clockStub.RightNow = time.Now()
+		sStore["closedRosterSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			RosterID:       "error",
+			State:          session.StateClosed,
+		}
+
+		_, got := service.CreateVote("thisIsAnID", agendaID, "closedRosterSession", "unavailable", "S")
+
+		assertValue(t, got, ErrSessionExpired)
+	})
+	t.Run("Accepts the members of the session roster", func(t *testing.T) {
+		sStore["rosterSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			RosterID:       "aRoster",
+		}
+
+		got, err := service.CreateVote("memberID", agendaID, "rosterSession", "017.912.290-05", "S")
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Weight, 3)
+	})
+	t.Run("Returns a Not In Roster error if the voter is not a roster member", func(t *testing.T) {
+		_, err := service.CreateVote("outsiderID", agendaID, "rosterSession", "52998224725", "S")
+
+		assertValue(t, err, ErrNotInRoster)
+	})
+	t.Run("Refuses non members before validating the document", func(t *testing.T) {
+		_, err := service.CreateVote("outsiderID", agendaID, "rosterSession", "unavailable", "S")
+
+		assertValue(t, err, ErrNotInRoster)
+	})
+	t.Run("Returns the roster error if the roster check fails", func(t *testing.T) {
+		sStore["brokenRosterSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			RosterID:       "error",
+		}
+
+		_, err := service.CreateVote("memberID", agendaID, "brokenRosterSession", "01791229005", "S")
+
+		assertValue(t, err.Error(), "ops, there was an error")
+	})
+	t.Run("Stores the votes of secret sessions apart", func(t *testing.T) {
+		sStore["secretSession"] = session.Session{
+			OriginalAgenda: agendaID,
+			Creation:       time.Now().Add(-time.Minute),
+			Duration:       time.Hour,
+			Secret:         true,
+		}
+
+		_, err := service.CreateVote("secretID", agendaID, "secretSession", "01791229005", "S")
+
+		assertValue(t, err, nil)
+		assertValue(t, repo.secretStore["secretID"].Vote, "S")
+		if _, ok := repo.voteStore["secretID"]; ok {
+			t.Errorf("the secret vote was stored with its voter")
+		}
+	})
+	t.Run("Returns an Duplicate Vote error if the secret vote is duplicate", func(t *testing.T) {
+		_, err := service.CreateVote("secretID", agendaID, "secretSession", "01791229005", "N")
+
+		assertValue(t, err, ErrDuplicateVote)
+	})
 	t.Run("Returns the error if there was any error", func(t *testing.T) {
 		associateID := "error"
 		sessionID := "sessionID"
 		document := "01791229005"
 		vote := "S"
-		_, got := service.CreateVote(associateID, sessionID, document, vote)
+		_, got := service.CreateVote(associateID, agendaID, sessionID, document, vote)
 		want := errors.New("ops, there was an error")
 
 		assertValue(t, got.Error(), want.Error())
@@ -2,11 +2,27 @@ package vote
 
 import "time"
 
-// Vote Representation of a vote
+// Vote Representation of a vote, the weight is the voting power of the
+// voter when the vote was cast. Votes cast by a proxy keep its document,
+// votes of ranked sessions keep the options in order of preference and
+// votes of approval sessions the approved options
 type Vote struct {
-	AssociateID string
-	SessionID   string
-	Document    string
-	Vote        string
-	Creation    time.Time
+	AssociateID   string
+	SessionID     string
+	Document      string
+	Vote          string
+	Weight        int
+	ProxyDocument string
+	Ranking       []string
+	Selections    []string
+	Creation      time.Time
+}
+
+// Choice Representation of what a ballot chooses, an option or an
+// abstention, the ranking of every option in ranked sessions or the
+// approved options in approval sessions
+type Choice struct {
+	Vote       string
+	Ranking    []string
+	Selections []string
 }
@@ -0,0 +1,57 @@
+package vote
+
+import (
+	"errors"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+// ErrBadRanking represents an error caused by a ranking missing or
+// repeating agenda options, or by a vote of a ranked session without one
+var ErrBadRanking = errors.New("Votes of ranked sessions must rank every agenda option once, in order of preference")
+
+// CreateRankedVote creates the vote of a ranked session, the ranking
+// lists every agenda option once, most preferred first. The vote is
+// stored as the first preference along with the whole ranking
+func (s *voteService) CreateRankedVote(id, agendaID, sessionID, document string, ranking []string) (Vote, error) {
+	sess, err := s.findSession(agendaID, sessionID)
+	if err != nil {
+		return Vote{}, err
+	}
+
+	if err := s.checkRanking(sess, ranking); err != nil {
+		return Vote{}, err
+	}
+
+	return s.cast(sess, Vote{
+		AssociateID: id,
+		SessionID:   sessionID,
+		Document:    NormalizeDocument(document),
+		Vote:        ranking[0],
+		Ranking:     ranking,
+	})
+}
+
+// checkRanking returns ErrBadVoteFormat if the session is not ranked and
+// ErrBadRanking unless the ranking is a permutation of the agenda options
+func (s *voteService) checkRanking(sess session.Session, ranking []string) error {
+	if sess.Method != session.RankedMethod {
+		return ErrBadVoteFormat
+	}
+
+	a, err := s.repo.FindAgenda(sess.OriginalAgenda)
+	if err != nil {
+		return err
+	}
+	if len(ranking) != len(a.Options) {
+		return ErrBadRanking
+	}
+	ranked := map[string]bool{}
+	for _, id := range ranking {
+		if ranked[id] || !a.HasOption(id) {
+			return ErrBadRanking
+		}
+		ranked[id] = true
+	}
+	return nil
+}
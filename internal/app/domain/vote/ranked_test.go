@@ -0,0 +1,70 @@
+package vote
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
+
+func TestCreateRankedVote(t *testing.T) {
+	agendaID := "agendaID"
+	sStore := map[string]session.Session{
+		"ranked": {
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Duration:       time.Hour,
+			Method:         session.RankedMethod,
+		},
+		"plurality": {
+			OriginalAgenda: agendaID,
+			Creation:       time.Now(),
+			Duration:       time.Hour,
+			Method:         session.PluralityMethod,
+		},
+	}
+	repo := VoteRepoStub{sStore, map[string]Vote{}, map[string]Vote{}, nil, nil}
+	clockStub := ClockStub{RightNow: time.Now()}
+	service := voteService{&repo, DocValidatorStub{}, &clockStub, 0}
+	t.Run("Stores the ranking and its first preference", func(t *testing.T) {
+		got, err := service.CreateRankedVote("anID", agendaID, "ranked", "01791229005", []string{"N", "S"})
+
+		assertValue(t, err, nil)
+		assertValue(t, got.Vote, "N")
+		if !reflect.DeepEqual(repo.voteStore["anID"].Ranking, []string{"N", "S"}) {
+			t.Errorf("got %v stored", repo.voteStore["anID"].Ranking)
+		}
+	})
+	t.Run("Returns a bad ranking error on incomplete rankings", func(t *testing.T) {
+		_, err := service.CreateRankedVote("otherID", agendaID, "ranked", "01791229005", []string{"S"})
+
+		assertValue(t, err, ErrBadRanking)
+	})
+	t.Run("Returns a bad ranking error on repeated options", func(t *testing.T) {
+		_, err := service.CreateRankedVote("otherID", agendaID, "ranked", "01791229005", []string{"S", "S"})
+
+		assertValue(t, err, ErrBadRanking)
+	})
+	t.Run("Returns a bad ranking error on unknown options", func(t *testing.T) {
+		_, err := service.CreateRankedVote("otherID", agendaID, "ranked", "01791229005", []string{"S", agenda.Abstention})
+
+		assertValue(t, err, ErrBadRanking)
+	})
+	t.Run("Returns a bad vote format error if the session is not ranked", func(t *testing.T) {
+		_, err := service.CreateRankedVote("otherID", agendaID, "plurality", "01791229005", []string{"S", "N"})
+
+		assertValue(t, err, ErrBadVoteFormat)
+	})
+	t.Run("Returns a bad ranking error on single votes of ranked sessions", func(t *testing.T) {
+		_, err := service.CreateVote("otherID", agendaID, "ranked", "01791229005", "S")
+
+		assertValue(t, err, ErrBadRanking)
+	})
+	t.Run("Accepts abstentions in ranked sessions", func(t *testing.T) {
+		_, err := service.CreateVote("abstainingID", agendaID, "ranked", "01791229005", agenda.Abstention)
+
+		assertValue(t, err, nil)
+	})
+}
@@ -1,9 +1,22 @@
 package vote
 
-import "github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+import (
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+)
 
 // Repository Persistency interface to serve the Session service
 type Repository interface {
 	InsertVote(Vote) error
+	InsertSecretVote(Vote) error
+	FindVote(string, string) (Vote, error)
+	UpdateVote(Change) error
+	DeleteVote(Change) error
 	FindSession(string) (session.Session, error)
+	FindAgenda(string) (agenda.Agenda, error)
+	IsRosterMember(string, string) (bool, error)
+	FindVoterWeight(string, string) (int, error)
+	InsertDelegation(Delegation, int) error
+	FindDelegation(string) (Delegation, error)
+	FindGrantorDelegations(string, string) ([]Delegation, error)
 }
@@ -2,5 +2,12 @@ package vote
 
 // Service describes the agenda service interface
 type Service interface {
-	CreateVote(string, string, string, string) (Vote, error)
+	CreateVote(string, string, string, string, string) (Vote, error)
+	ChangeVote(string, string, string, string, Choice) (Vote, error)
+	RetractVote(string, string, string, string) error
+	CreateDelegation(DelegationParams) (Delegation, error)
+	FindDelegation(string) (Delegation, error)
+	CreateProxyVote(string, string, string, string, Choice) (Vote, error)
+	CreateRankedVote(string, string, string, string, []string) (Vote, error)
+	CreateApprovalVote(string, string, string, string, []string) (Vote, error)
 }
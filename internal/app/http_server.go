@@ -2,7 +2,6 @@ package server
 
 import (
 	"net/http"
-	"regexp"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/ports"
 )
@@ -17,15 +16,6 @@ type HTTPLogger interface {
 	Info(...interface{})
 }
 
-type httpServer struct {
-	routes []*route
-}
-
-type route struct {
-	pattern *regexp.Regexp
-	handler http.Handler
-}
-
 // NewHTTPServer creates a new http handler
 func NewHTTPServer(
 	l HTTPLogger,
@@ -33,100 +23,35 @@ func NewHTTPServer(
 	sH ports.SessionHandler,
 	vH ports.VoteHandler,
 	rH ports.ResultHandler,
+	asH ports.AssociateHandler,
+	roH ports.RosterHandler,
+	deH ports.DelegationHandler,
 ) HTTPServer {
 	logger := newLoggerMiddleware(l)
-	routes := []*route{
-		createRoute("/agenda$", logger(handleCreateAgenda(aH))),
-		createRoute("/agenda/[^/]{0,}$", logger(handleFindAgenda(aH))),
-		createRoute("/agenda/[^/]{0,}/session$", logger(handleCreateSession(sH))),
-		createRoute("/agenda/[^/]{0,}/session/[^/]{0,}$", logger(handleFindSession(sH))),
-		createRoute("/agenda/[^/]{0,}/session/[^/]{0,}/vote$", logger(handleCreateVote(vH))),
-		createRoute("/agenda/[^/]{0,}/session/[^/]{0,}/result$", logger(handleSessionResult(rH))),
-	}
-	return &httpServer{
-		routes: routes,
-	}
-}
-
-func (s *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	for _, route := range s.routes {
-		if route.pattern.MatchString(r.URL.Path) {
-			route.handler.ServeHTTP(w, r)
-			return
-		}
-	}
-	http.NotFound(w, r)
-}
-
-func handleCreateAgenda(h ports.AgendaHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			h.Post(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func handleCreateSession(h ports.AgendaHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			h.Post(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func handleFindAgenda(h ports.SessionHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			h.Get(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func handleFindSession(h ports.SessionHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			h.Get(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func handleCreateVote(h ports.VoteHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			h.Post(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func handleSessionResult(h ports.ResultHandler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
-			h.Get(w, r)
-			return
-		}
-		methodNotAllowed(w, r)
-	})
-}
-
-func createRoute(pattern string, handler http.Handler) *route {
-	rg := regexp.MustCompile(pattern)
-	return &route{
-		pattern: rg,
-		handler: handler,
-	}
-}
 
-func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusMethodNotAllowed)
-	w.Write([]byte{})
+	r := &router{}
+	r.handle(http.MethodPost, "/agenda", http.HandlerFunc(aH.Post), logger)
+	r.handle(http.MethodGet, "/agenda", http.HandlerFunc(aH.List), logger)
+	r.handle(http.MethodGet, "/agenda/{agendaID}", http.HandlerFunc(aH.Get), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session", http.HandlerFunc(sH.Post), logger)
+	r.handle(http.MethodGet, "/agenda/{agendaID}/session", http.HandlerFunc(sH.List), logger)
+	r.handle(http.MethodGet, "/agenda/{agendaID}/session/{sessionID}", http.HandlerFunc(sH.Get), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session/{sessionID}/close", http.HandlerFunc(sH.Close), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session/{sessionID}/extend", http.HandlerFunc(sH.Extend), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session/{sessionID}/cancel", http.HandlerFunc(sH.Cancel), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session/{sessionID}/reopen", http.HandlerFunc(sH.Reopen), logger)
+	r.handle(http.MethodPost, "/agenda/{agendaID}/session/{sessionID}/vote", http.HandlerFunc(vH.Post), logger)
+	r.handle(http.MethodPut, "/agenda/{agendaID}/session/{sessionID}/vote/{associateID:text}", http.HandlerFunc(vH.Put), logger)
+	r.handle(http.MethodDelete, "/agenda/{agendaID}/session/{sessionID}/vote/{associateID:text}", http.HandlerFunc(vH.Delete), logger)
+	r.handle(http.MethodGet, "/agenda/{agendaID}/session/{sessionID}/result", http.HandlerFunc(rH.Get), logger)
+	r.handle(http.MethodPost, "/associate", http.HandlerFunc(asH.Post), logger)
+	r.handle(http.MethodPost, "/associate/import", http.HandlerFunc(asH.Import), logger)
+	r.handle(http.MethodGet, "/associate/{associateID:text}", http.HandlerFunc(asH.Get), logger)
+	r.handle(http.MethodPut, "/associate/{associateID:text}", http.HandlerFunc(asH.Put), logger)
+	r.handle(http.MethodDelete, "/associate/{associateID:text}", http.HandlerFunc(asH.Delete), logger)
+	r.handle(http.MethodPost, "/roster", http.HandlerFunc(roH.Post), logger)
+	r.handle(http.MethodGet, "/roster/{rosterID}", http.HandlerFunc(roH.Get), logger)
+	r.handle(http.MethodPost, "/delegation", http.HandlerFunc(deH.Post), logger)
+	r.handle(http.MethodGet, "/delegation/{delegationID}", http.HandlerFunc(deH.Get), logger)
+	return r
 }
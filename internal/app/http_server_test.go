@@ -4,6 +4,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/ports"
+)
+
+const (
+	agendaID  = "36df597d-a3b7-45cd-b65a-439c0900649e"
+	sessionID = "0b0a0c55-2f3e-4c5b-9a37-5f5a2d0b6f61"
 )
 
 type agendaHandlerStub struct {
@@ -13,6 +20,9 @@ type agendaHandlerStub struct {
 	P struct {
 		CalledWith []interface{}
 	}
+	L struct {
+		CalledWith []interface{}
+	}
 }
 
 func (h *agendaHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
@@ -23,6 +33,10 @@ func (h *agendaHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
 	h.G.CalledWith = []interface{}{w, r}
 }
 
+func (h *agendaHandlerStub) List(w http.ResponseWriter, r *http.Request) {
+	h.L.CalledWith = []interface{}{w, r}
+}
+
 type sessionHandlerStub struct {
 	G struct {
 		CalledWith []interface{}
@@ -30,6 +44,21 @@ type sessionHandlerStub struct {
 	P struct {
 		CalledWith []interface{}
 	}
+	L struct {
+		CalledWith []interface{}
+	}
+	C struct {
+		CalledWith []interface{}
+	}
+	E struct {
+		CalledWith []interface{}
+	}
+	X struct {
+		CalledWith []interface{}
+	}
+	R struct {
+		CalledWith []interface{}
+	}
 }
 
 func (h *sessionHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
@@ -40,16 +69,50 @@ func (h *sessionHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
 	h.G.CalledWith = []interface{}{w, r}
 }
 
+func (h *sessionHandlerStub) List(w http.ResponseWriter, r *http.Request) {
+	h.L.CalledWith = []interface{}{w, r}
+}
+
+func (h *sessionHandlerStub) Close(w http.ResponseWriter, r *http.Request) {
+	h.C.CalledWith = []interface{}{w, r}
+}
+
+func (h *sessionHandlerStub) Extend(w http.ResponseWriter, r *http.Request) {
+	h.E.CalledWith = []interface{}{w, r}
+}
+
+func (h *sessionHandlerStub) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.X.CalledWith = []interface{}{w, r}
+}
+
+func (h *sessionHandlerStub) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.R.CalledWith = []interface{}{w, r}
+}
+
 type voteHandlerStub struct {
 	P struct {
 		CalledWith []interface{}
 	}
+	U struct {
+		CalledWith []interface{}
+	}
+	D struct {
+		CalledWith []interface{}
+	}
 }
 
 func (h *voteHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
 	h.P.CalledWith = []interface{}{w, r}
 }
 
+func (h *voteHandlerStub) Put(w http.ResponseWriter, r *http.Request) {
+	h.U.CalledWith = []interface{}{w, r}
+}
+
+func (h *voteHandlerStub) Delete(w http.ResponseWriter, r *http.Request) {
+	h.D.CalledWith = []interface{}{w, r}
+}
+
 type resultHandlerStub struct {
 	G struct {
 		CalledWith []interface{}
@@ -60,6 +123,78 @@ func (h *resultHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
 	h.G.CalledWith = []interface{}{w, r}
 }
 
+type associateHandlerStub struct {
+	P struct {
+		CalledWith []interface{}
+	}
+	G struct {
+		CalledWith []interface{}
+	}
+	U struct {
+		CalledWith []interface{}
+	}
+	D struct {
+		CalledWith []interface{}
+	}
+	I struct {
+		CalledWith []interface{}
+	}
+}
+
+func (h *associateHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
+	h.P.CalledWith = []interface{}{w, r}
+}
+
+func (h *associateHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
+	h.G.CalledWith = []interface{}{w, r}
+}
+
+func (h *associateHandlerStub) Put(w http.ResponseWriter, r *http.Request) {
+	h.U.CalledWith = []interface{}{w, r}
+}
+
+func (h *associateHandlerStub) Delete(w http.ResponseWriter, r *http.Request) {
+	h.D.CalledWith = []interface{}{w, r}
+}
+
+func (h *associateHandlerStub) Import(w http.ResponseWriter, r *http.Request) {
+	h.I.CalledWith = []interface{}{w, r}
+}
+
+type rosterHandlerStub struct {
+	P struct {
+		CalledWith []interface{}
+	}
+	G struct {
+		CalledWith []interface{}
+	}
+}
+
+func (h *rosterHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
+	h.P.CalledWith = []interface{}{w, r}
+}
+
+func (h *rosterHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
+	h.G.CalledWith = []interface{}{w, r}
+}
+
+type delegationHandlerStub struct {
+	P struct {
+		CalledWith []interface{}
+	}
+	G struct {
+		CalledWith []interface{}
+	}
+}
+
+func (h *delegationHandlerStub) Post(w http.ResponseWriter, r *http.Request) {
+	h.P.CalledWith = []interface{}{w, r}
+}
+
+func (h *delegationHandlerStub) Get(w http.ResponseWriter, r *http.Request) {
+	h.G.CalledWith = []interface{}{w, r}
+}
+
 type loggerStub struct {
 	CalledWith []interface{}
 }
@@ -74,10 +209,13 @@ var (
 	sH  = sessionHandlerStub{}
 	vH  = voteHandlerStub{}
 	rH  = resultHandlerStub{}
+	asH = associateHandlerStub{}
+	roH = rosterHandlerStub{}
+	deH = delegationHandlerStub{}
 )
 
 func TestAgendaEndpoint(t *testing.T) {
-	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH)
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
 	t.Run("calls agendaHandler.Post in a /agenda http POST", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda", nil)
 		response := httptest.NewRecorder()
@@ -85,16 +223,25 @@ func TestAgendaEndpoint(t *testing.T) {
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, aH.P.CalledWith, response)
-		assertInsideSlice(t, aH.P.CalledWith, request)
+		assertRequest(t, aH.P.CalledWith, request)
+	})
+	t.Run("calls agendaHandler.List in a /agenda http GET", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, aH.L.CalledWith, response)
+		assertRequest(t, aH.L.CalledWith, request)
 	})
-	t.Run("calls agendaHandler.Get in a /agenda http GET", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+	t.Run("calls agendaHandler.Get in a /agenda/id http GET", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID, nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, aH.G.CalledWith, response)
-		assertInsideSlice(t, aH.G.CalledWith, request)
+		assertRequest(t, aH.G.CalledWith, request)
 	})
 	t.Run("returns method not allowed for any other method", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPatch, "/agenda", nil)
@@ -119,27 +266,53 @@ func TestAgendaEndpoint(t *testing.T) {
 }
 
 func TestSessionEndpoint(t *testing.T) {
-	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH)
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
 	t.Run("calls sessionHandler.Post in a /agenda/id/session http POST", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", nil)
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/"+agendaID+"/session", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, sH.P.CalledWith, response)
-		assertInsideSlice(t, sH.P.CalledWith, request)
+		assertRequest(t, sH.P.CalledWith, request)
+	})
+	t.Run("calls sessionHandler.List in a /agenda/id/session http GET", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID+"/session", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, sH.L.CalledWith, response)
+		assertRequest(t, sH.L.CalledWith, request)
 	})
 	t.Run("calls agendaHandler.Get in a /agenda/id/session/id http GET", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID/session/otherID", nil)
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID+"/session/"+sessionID, nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, sH.G.CalledWith, response)
-		assertInsideSlice(t, sH.G.CalledWith, request)
+		assertRequest(t, sH.G.CalledWith, request)
+	})
+	t.Run("calls the sessionHandler administrative actions in their http POSTs", func(t *testing.T) {
+		actions := map[string]*[]interface{}{
+			"close":  &sH.C.CalledWith,
+			"extend": &sH.E.CalledWith,
+			"cancel": &sH.X.CalledWith,
+			"reopen": &sH.R.CalledWith,
+		}
+		for action, calledWith := range actions {
+			request, _ := http.NewRequest(http.MethodPost, "/agenda/"+agendaID+"/session/"+sessionID+"/"+action, nil)
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, request)
+
+			assertInsideSlice(t, *calledWith, response)
+			assertRequest(t, *calledWith, request)
+		}
 	})
 	t.Run("returns method not allowed for any other method", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPatch, "/agenda/anID/session", nil)
+		request, _ := http.NewRequest(http.MethodPatch, "/agenda/"+agendaID+"/session", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
@@ -148,8 +321,8 @@ func TestSessionEndpoint(t *testing.T) {
 	})
 	t.Run("calls logger.Info in /agenda/id/session/id http Requests", func(t *testing.T) {
 		log.CalledWith = []interface{}{}
-		endpoint := "/agenda/id/session/id"
-		method := http.MethodPost
+		endpoint := "/agenda/" + agendaID + "/session/" + sessionID
+		method := http.MethodGet
 		request, _ := http.NewRequest(method, endpoint, nil)
 		response := httptest.NewRecorder()
 
@@ -161,18 +334,36 @@ func TestSessionEndpoint(t *testing.T) {
 }
 
 func TestVoteEndpoint(t *testing.T) {
-	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH)
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
 	t.Run("calls voteHandler.Post in a /agenda/id/session/id/vote http POST", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/id/vote", nil)
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/"+agendaID+"/session/"+sessionID+"/vote", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, vH.P.CalledWith, response)
-		assertInsideSlice(t, vH.P.CalledWith, request)
+		assertRequest(t, vH.P.CalledWith, request)
+	})
+	t.Run("calls voteHandler.Put in a /agenda/id/session/id/vote/id http PUT", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/agenda/"+agendaID+"/session/"+sessionID+"/vote/associateID", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, vH.U.CalledWith, response)
+		assertRequest(t, vH.U.CalledWith, request)
+	})
+	t.Run("calls voteHandler.Delete in a /agenda/id/session/id/vote/id http DELETE", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodDelete, "/agenda/"+agendaID+"/session/"+sessionID+"/vote/associateID", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, vH.D.CalledWith, response)
+		assertRequest(t, vH.D.CalledWith, request)
 	})
 	t.Run("returns method not allowed for any other method", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPatch, "/agenda/anID/session/id/vote", nil)
+		request, _ := http.NewRequest(http.MethodPatch, "/agenda/"+agendaID+"/session/"+sessionID+"/vote", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
@@ -181,7 +372,7 @@ func TestVoteEndpoint(t *testing.T) {
 	})
 	t.Run("calls logger.Info in /agenda/id/session/id http Requests", func(t *testing.T) {
 		log.CalledWith = []interface{}{}
-		endpoint := "/agenda/id/session/id/vote"
+		endpoint := "/agenda/" + agendaID + "/session/" + sessionID + "/vote"
 		method := http.MethodPost
 		request, _ := http.NewRequest(method, endpoint, nil)
 		response := httptest.NewRecorder()
@@ -194,18 +385,18 @@ func TestVoteEndpoint(t *testing.T) {
 }
 
 func TestResultEndpoint(t *testing.T) {
-	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH)
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
 	t.Run("calls resultHandler.Get in a /agenda/id/session/id/result http GET", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/id/result", nil)
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID+"/session/"+sessionID+"/result", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
 
 		assertInsideSlice(t, rH.G.CalledWith, response)
-		assertInsideSlice(t, rH.G.CalledWith, request)
+		assertRequest(t, rH.G.CalledWith, request)
 	})
 	t.Run("returns method not allowed for any other method", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPatch, "/agenda/anID/session/id/result", nil)
+		request, _ := http.NewRequest(http.MethodPatch, "/agenda/"+agendaID+"/session/"+sessionID+"/result", nil)
 		response := httptest.NewRecorder()
 
 		server.ServeHTTP(response, request)
@@ -214,7 +405,7 @@ func TestResultEndpoint(t *testing.T) {
 	})
 	t.Run("calls logger.Info in /agenda/id/session/id http Requests", func(t *testing.T) {
 		log.CalledWith = []interface{}{}
-		endpoint := "/agenda/id/session/id/result"
+		endpoint := "/agenda/" + agendaID + "/session/" + sessionID + "/result"
 		method := http.MethodGet
 		request, _ := http.NewRequest(method, endpoint, nil)
 		response := httptest.NewRecorder()
@@ -226,6 +417,191 @@ func TestResultEndpoint(t *testing.T) {
 	})
 }
 
+func TestAssociateEndpoint(t *testing.T) {
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
+	t.Run("calls associateHandler.Post in a /associate http POST", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/associate", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, asH.P.CalledWith, response)
+		assertRequest(t, asH.P.CalledWith, request)
+	})
+	t.Run("calls associateHandler.Get, Put and Delete in /associate/id", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+			request, _ := http.NewRequest(method, "/associate/A-123", nil)
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, request)
+
+			assertValue(t, response.Code, http.StatusOK)
+		}
+		assertValue(t, ports.PathParam(asH.G.CalledWith[1].(*http.Request), "associateID"), "A-123")
+		assertValue(t, asH.U.CalledWith[1].(*http.Request).Method, http.MethodPut)
+		assertValue(t, asH.D.CalledWith[1].(*http.Request).Method, http.MethodDelete)
+	})
+	t.Run("calls associateHandler.Import in a /associate/import http POST", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/associate/import", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, asH.I.CalledWith, response)
+		assertRequest(t, asH.I.CalledWith, request)
+	})
+	t.Run("returns method not allowed for any other method", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPatch, "/associate/A-123", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusMethodNotAllowed)
+		assertValue(t, response.Header().Get("Allow"), "DELETE, GET, PUT")
+	})
+	t.Run("falls through to the next route matching the path and method", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/associate/import", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusOK)
+		assertValue(t, ports.PathParam(asH.G.CalledWith[1].(*http.Request), "associateID"), "import")
+	})
+	t.Run("allows the methods of every route matching the path", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPatch, "/associate/import", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusMethodNotAllowed)
+		assertValue(t, response.Header().Get("Allow"), "DELETE, GET, POST, PUT")
+	})
+}
+
+func TestRosterEndpoint(t *testing.T) {
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
+	t.Run("calls rosterHandler.Post in a /roster http POST", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/roster", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, roH.P.CalledWith, response)
+		assertRequest(t, roH.P.CalledWith, request)
+	})
+	t.Run("calls rosterHandler.Get in a /roster/id http GET", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/roster/"+agendaID, nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, roH.G.CalledWith, response)
+		assertValue(t, ports.PathParam(roH.G.CalledWith[1].(*http.Request), "rosterID"), agendaID)
+	})
+	t.Run("returns bad request if the id is not a UUID", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/roster/council", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+func TestDelegationEndpoint(t *testing.T) {
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
+	t.Run("calls delegationHandler.Post in a /delegation http POST", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/delegation", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, deH.P.CalledWith, response)
+		assertRequest(t, deH.P.CalledWith, request)
+	})
+	t.Run("calls delegationHandler.Get in a /delegation/id http GET", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/delegation/"+agendaID, nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertInsideSlice(t, deH.G.CalledWith, response)
+		assertValue(t, ports.PathParam(deH.G.CalledWith[1].(*http.Request), "delegationID"), agendaID)
+	})
+}
+
+func TestRouter(t *testing.T) {
+	server := NewHTTPServer(&log, &aH, &sH, &vH, &rH, &asH, &roH, &deH)
+	t.Run("passes the path params to the handler", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID+"/session/"+sessionID+"/result", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		got := rH.G.CalledWith[1].(*http.Request)
+		assertValue(t, ports.PathParam(got, "agendaID"), agendaID)
+		assertValue(t, ports.PathParam(got, "sessionID"), sessionID)
+	})
+	t.Run("returns not found for paths only containing a route", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/foo/agenda/"+agendaID, nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusNotFound)
+	})
+	t.Run("returns bad request if an id is not a UUID", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/"+agendaID+"/session/notAnID", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("informs the allowed methods on method not allowed", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodDelete, "/agenda/"+agendaID+"/session", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		assertValue(t, response.Code, http.StatusMethodNotAllowed)
+		assertValue(t, response.Header().Get("Allow"), "GET, POST")
+	})
+	t.Run("applies the route middlewares in order", func(t *testing.T) {
+		var calls []string
+		mark := func(name string) middleware {
+			return func(h http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls = append(calls, name)
+					h.ServeHTTP(w, r)
+				})
+			}
+		}
+		r := &router{}
+		r.handle(http.MethodGet, "/ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "handler")
+		}), mark("first"), mark("second"))
+		request, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+
+		r.ServeHTTP(httptest.NewRecorder(), request)
+
+		if len(calls) != 3 || calls[0] != "first" || calls[1] != "second" || calls[2] != "handler" {
+			t.Errorf("got %v, want [first second handler]", calls)
+		}
+	})
+}
+
+func assertRequest(t *testing.T, a []interface{}, want *http.Request) {
+	t.Helper()
+	for _, v := range a {
+		if r, ok := v.(*http.Request); ok && r.Method == want.Method && r.URL.Path == want.URL.Path {
+			return
+		}
+	}
+	t.Errorf("Did not found a %s %s request in %v", want.Method, want.URL.Path, a)
+}
+
 func assertValue(t *testing.T, got, want interface{}) {
 	t.Helper()
 	if got != want {
@@ -2,18 +2,13 @@ package ports
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
 )
 
-type agendaOpts struct {
-	Description string `json:"description"`
-}
-
 type agendaHandler struct {
 	service agenda.Service
 }
@@ -22,6 +17,7 @@ type agendaHandler struct {
 type AgendaHandler interface {
 	Post(w http.ResponseWriter, r *http.Request)
 	Get(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
 }
 
 // NewAgendaHandler creates a new http agenda handler
@@ -33,63 +29,96 @@ func NewAgendaHandler(s agenda.Service) AgendaHandler {
 
 // Post http translator
 func (h *agendaHandler) Post(w http.ResponseWriter, r *http.Request) {
-	var o agendaOpts
+	var o HTTPCreateAgendaReq
 	err := decodeJSONBody(r, &o, false)
 	if err != nil {
-		var mr *malformedRequest
-		if errors.As(err, &mr) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(mr.status)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: mr.msg,
-			})
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(HTTPError{
-			Message: fmt.Sprint(err),
-		})
+		writeError(w, err)
 		return
 	}
 
-	agenda, err := h.service.CreateAgenda(o.Description)
+	options := make([]agenda.Option, len(o.Options))
+	for i, opt := range o.Options {
+		options[i] = agenda.Option{ID: opt.ID, Description: opt.Description}
+	}
+
+	a, err := h.service.CreateAgenda(o.Description, options)
 	if err != nil {
-		internalServerError(w)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(HTTPCreateAgendaRes{
-		ID:          agenda.ID,
-		Description: agenda.Description,
-	})
+	json.NewEncoder(w).Encode(newHTTPAgendaRes(a))
 	return
 }
 
 func (h *agendaHandler) Get(w http.ResponseWriter, r *http.Request) {
-	id := strings.TrimPrefix(r.URL.Path, "/agenda/")
+	id := PathParam(r, "agendaID")
 
-	agenda, err := h.service.FindAgenda(id)
+	a, err := h.service.FindAgenda(id)
 	if err != nil {
-		if err.Error() == "Agenda not found" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: "Agenda not found",
-			})
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPAgendaRes(a))
+	return
+}
+
+// List http translator
+func (h *agendaHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	f := agenda.Filter{
+		Status:      query.Get("status"),
+		Description: query.Get("description"),
+		Sort:        query.Get("sort"),
+		Cursor:      query.Get("cursor"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil {
+			writeError(w, agenda.ErrBadFilter)
 			return
 		}
-		internalServerError(w)
+		f.Limit = l
+	}
+
+	page, err := h.service.ListAgendas(f)
+	if err != nil {
+		writeError(w, err)
 		return
 	}
 
+	res := HTTPListAgendasRes{
+		Agendas:    make([]HTTPAgendaSummary, len(page.Agendas)),
+		NextCursor: page.NextCursor,
+	}
+	for i, a := range page.Agendas {
+		res.Agendas[i] = HTTPAgendaSummary{
+			ID:          a.ID,
+			Description: a.Description,
+			Status:      a.Status,
+			Creation:    a.Creation.Format(time.RFC3339),
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(HTTPCreateAgendaRes{
-		ID:          agenda.ID,
-		Description: agenda.Description,
-	})
+	json.NewEncoder(w).Encode(res)
 	return
 }
+
+func newHTTPAgendaRes(a agenda.Agenda) HTTPCreateAgendaRes {
+	options := make([]HTTPOption, len(a.Options))
+	for i, o := range a.Options {
+		options[i] = HTTPOption{ID: o.ID, Description: o.Description}
+	}
+	return HTTPCreateAgendaRes{
+		ID:          a.ID,
+		Description: a.Description,
+		Options:     options,
+	}
+}
@@ -19,21 +19,25 @@ type AgendaServiceStub struct {
 	LastDeliveredAgenda agenda.Agenda
 }
 
-func (s *AgendaServiceStub) CreateAgenda(description string) (agenda.Agenda, error) {
-	s.CalledWith = []interface{}{description}
+func (s *AgendaServiceStub) CreateAgenda(description string, options []agenda.Option) (agenda.Agenda, error) {
+	s.CalledWith = []interface{}{description, options}
 	if description == "ERROR" {
 		return agenda.Agenda{}, errors.New("A ERROR")
 	}
+	if len(options) == 1 {
+		return agenda.Agenda{}, agenda.ErrTooFewOptions
+	}
 	return agenda.Agenda{
 		ID:          "36df597d-a3b7-45cd-b65a-439c0900649e",
 		Description: description,
+		Options:     options,
 	}, nil
 }
 
 func (s *AgendaServiceStub) FindAgenda(id string) (agenda.Agenda, error) {
 	s.CalledWith = []interface{}{id}
 	if id == "notFound" {
-		return agenda.Agenda{}, errors.New("Agenda not found")
+		return agenda.Agenda{}, agenda.ErrAgendaNotFound
 	}
 	if id == "otherError" {
 		return agenda.Agenda{}, errors.New("Any error at all")
@@ -46,6 +50,24 @@ func (s *AgendaServiceStub) FindAgenda(id string) (agenda.Agenda, error) {
 	return s.LastDeliveredAgenda, nil
 }
 
+func (s *AgendaServiceStub) ListAgendas(f agenda.Filter) (agenda.Page, error) {
+	s.CalledWith = []interface{}{f}
+	if f.Status == "unknown" {
+		return agenda.Page{}, agenda.ErrBadFilter
+	}
+	if f.Description == "ERROR" {
+		return agenda.Page{}, errors.New("A ERROR")
+	}
+	return agenda.Page{
+		Agendas: []agenda.Agenda{{
+			ID:          "36df597d-a3b7-45cd-b65a-439c0900649e",
+			Description: "a description",
+			Status:      agenda.StatusNew,
+		}},
+		NextCursor: "aCursor",
+	}, nil
+}
+
 var validAgendaReqBody, _ = json.Marshal(HTTPCreateAgendaReq{
 	Description: "a description",
 })
@@ -77,7 +99,7 @@ func TestPOSTAgenda(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda", bytes.NewBuffer(validAgendaReqBody))
 		response := httptest.NewRecorder()
 
-		wants := []string{"id", "description"}
+		wants := []string{"id", "description", "options"}
 
 		h.Post(response, request)
 		respMap := map[string]interface{}{}
@@ -101,6 +123,40 @@ func TestPOSTAgenda(t *testing.T) {
 
 		assertInsideSlice(t, agendaService.CalledWith, description)
 	})
+	t.Run("Should call the CreateAgenda with the informed options", func(t *testing.T) {
+		requestBody, _ := json.Marshal(HTTPCreateAgendaReq{
+			Description: "a description",
+			Options: []HTTPOption{
+				{ID: "alice", Description: "Alice"},
+				{ID: "bob", Description: "Bob"},
+			},
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda", bytes.NewBuffer(requestBody))
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		want := []agenda.Option{
+			{ID: "alice", Description: "Alice"},
+			{ID: "bob", Description: "Bob"},
+		}
+		if !reflect.DeepEqual(agendaService.CalledWith[1], want) {
+			t.Errorf("got %v, want %v", agendaService.CalledWith[1], want)
+		}
+	})
+	t.Run("Should return a BadRequest if the options are invalid", func(t *testing.T) {
+		requestBody, _ := json.Marshal(HTTPCreateAgendaReq{
+			Description: "a description",
+			Options:     []HTTPOption{{ID: "alone"}},
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda", bytes.NewBuffer(requestBody))
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", agenda.ErrTooFewOptions.Error())
+	})
 	t.Run("Should return a internal server error if there was an error creating an agenda", func(t *testing.T) {
 		description := "ERROR"
 		requestBody, _ := json.Marshal(map[string]string{
@@ -130,6 +186,7 @@ func TestGETAgenda(t *testing.T) {
 	h := NewAgendaHandler(&agendaService)
 	t.Run("Should return a 200 if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "anID"})
 		response := httptest.NewRecorder()
 
 		want := http.StatusOK
@@ -140,6 +197,7 @@ func TestGETAgenda(t *testing.T) {
 	})
 	t.Run("Should return a Agenda if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "anID"})
 		response := httptest.NewRecorder()
 
 		wants := []string{"id", "description"}
@@ -156,6 +214,7 @@ func TestGETAgenda(t *testing.T) {
 	})
 	t.Run("Should call find Agenda with the right params", func(t *testing.T) {
 		getRequest, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+		getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "anID"})
 		response := httptest.NewRecorder()
 		h.Get(response, getRequest)
 
@@ -165,6 +224,7 @@ func TestGETAgenda(t *testing.T) {
 		t.Run("Should return a 404", func(t *testing.T) {
 			want := http.StatusNotFound
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/notFound"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "notFound"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -176,6 +236,7 @@ func TestGETAgenda(t *testing.T) {
 		t.Run("Should return a 500", func(t *testing.T) {
 			want := http.StatusInternalServerError
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/otherError"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "otherError"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -185,6 +246,60 @@ func TestGETAgenda(t *testing.T) {
 	})
 }
 
+func TestLISTAgenda(t *testing.T) {
+	agendaService := AgendaServiceStub{}
+	h := NewAgendaHandler(&agendaService)
+	t.Run("Should return a page of agendas", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda", nil)
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideJSON(t, response.Body, "nextCursor", "aCursor")
+	})
+	t.Run("Should call ListAgendas with the query params", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda?status=voting&description=budget&sort=-creation&cursor=aCursor&limit=5", nil)
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		want := agenda.Filter{
+			Status:      "voting",
+			Description: "budget",
+			Sort:        "-creation",
+			Cursor:      "aCursor",
+			Limit:       5,
+		}
+		assertInsideSlice(t, agendaService.CalledWith, want)
+	})
+	t.Run("Should return a BadRequest if the limit is not a number", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda?limit=ten", nil)
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("Should return a BadRequest if the filter is invalid", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda?status=unknown", nil)
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", agenda.ErrBadFilter.Error())
+	})
+	t.Run("Should return a 500 if there was any other error", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda?description=ERROR", nil)
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+}
+
 func assertStatus(t *testing.T, got, want int) {
 	t.Helper()
 	if got != want {
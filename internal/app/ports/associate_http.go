@@ -0,0 +1,170 @@
+package ports
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+)
+
+type associateHandler struct {
+	service associate.Service
+}
+
+// AssociateHandler describes a http handler interface
+type AssociateHandler interface {
+	Post(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	Put(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	Import(w http.ResponseWriter, r *http.Request)
+}
+
+// NewAssociateHandler creates a new http associate handler
+func NewAssociateHandler(s associate.Service) AssociateHandler {
+	return &associateHandler{
+		service: s,
+	}
+}
+
+// Post http translator
+func (h *associateHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var o HTTPAssociateReq
+	err := decodeJSONBody(r, &o, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	a, err := h.service.CreateAssociate(o.ID, associate.Params{
+		Document: o.Document,
+		Name:     o.Name,
+		Weight:   o.Weight,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newHTTPAssociateRes(a))
+	return
+}
+
+// Get http translator
+func (h *associateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	a, err := h.service.FindAssociate(PathParam(r, "associateID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPAssociateRes(a))
+	return
+}
+
+// Put http translator
+func (h *associateHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var o HTTPAssociateReq
+	err := decodeJSONBody(r, &o, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	a, err := h.service.UpdateAssociate(PathParam(r, "associateID"), associate.Params{
+		Document: o.Document,
+		Name:     o.Name,
+		Weight:   o.Weight,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPAssociateRes(a))
+	return
+}
+
+// Delete http translator, associates are deactivated instead of removed
+// so their past votes keep a reference
+func (h *associateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	a, err := h.service.DeactivateAssociate(PathParam(r, "associateID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPAssociateRes(a))
+	return
+}
+
+// Import http translator, the csv body is imported while the report of
+// each row is streamed as {"rows": [...], "summary": {...}}. Errors found
+// before the first row are answered with the usual status, afterwards
+// they can only be informed in the summary
+func (h *associateHandler) Import(w http.ResponseWriter, r *http.Request) {
+	enc := json.NewEncoder(w)
+	rows := 0
+	begin := func() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"rows":[`)
+	}
+
+	summary, err := associate.ImportCSV(h.service, r.Body, func(row associate.ImportRow) error {
+		if rows == 0 {
+			begin()
+		} else if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+		rows++
+		return enc.Encode(HTTPImportRowRes{
+			Row:      row.Row,
+			ID:       row.ID,
+			Document: row.Document,
+			Accepted: row.Accepted,
+			Reason:   row.Reason,
+		})
+	})
+	if err != nil && rows == 0 {
+		writeError(w, err)
+		return
+	}
+	if rows == 0 {
+		begin()
+	}
+
+	res := HTTPImportSummaryRes{
+		Accepted: summary.Accepted,
+		Rejected: summary.Rejected,
+	}
+	if err != nil {
+		res.Error = "There was an unexpected error, the import stopped after the last reported row"
+	}
+	io.WriteString(w, `],"summary":`)
+	enc.Encode(res)
+	io.WriteString(w, "}")
+	return
+}
+
+func newHTTPAssociateRes(a associate.Associate) HTTPAssociateRes {
+	return HTTPAssociateRes{
+		ID:       a.ID,
+		Document: a.Document,
+		Name:     a.Name,
+		Weight:   a.Weight,
+		Active:   a.Active,
+		Creation: a.Creation.Format(time.RFC3339),
+		Update:   a.Update.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,301 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+type AssociateServiceStub struct {
+	CalledWith []interface{}
+}
+
+func (s *AssociateServiceStub) associate(id string, p associate.Params) (associate.Associate, error) {
+	s.CalledWith = []interface{}{id, p.Document, p.Name, p.Weight}
+	switch {
+	case id == "ERROR":
+		return associate.Associate{}, errors.New("A ERROR")
+	case id == "notFound":
+		return associate.Associate{}, associate.ErrAssociateNotFound
+	case id == "duplicated":
+		return associate.Associate{}, associate.ErrDuplicateAssociate
+	case p.Document == "invalid":
+		return associate.Associate{}, vote.ErrInvalidDocument
+	}
+	return associate.Associate{
+		ID:       id,
+		Document: p.Document,
+		Name:     p.Name,
+		Weight:   p.Weight,
+		Active:   true,
+		Creation: time.Now(),
+		Update:   time.Now(),
+	}, nil
+}
+
+func (s *AssociateServiceStub) CreateAssociate(id string, p associate.Params) (associate.Associate, error) {
+	return s.associate(id, p)
+}
+
+func (s *AssociateServiceStub) FindAssociate(id string) (associate.Associate, error) {
+	return s.associate(id, associate.Params{})
+}
+
+func (s *AssociateServiceStub) UpdateAssociate(id string, p associate.Params) (associate.Associate, error) {
+	return s.associate(id, p)
+}
+
+func (s *AssociateServiceStub) DeactivateAssociate(id string) (associate.Associate, error) {
+	a, err := s.associate(id, associate.Params{})
+	a.Active = false
+	return a, err
+}
+
+func (s *AssociateServiceStub) ImportAssociate(id string, p associate.Params) (associate.Associate, error) {
+	return s.associate(id, p)
+}
+
+func (s *AssociateServiceStub) IsEligible(document string) (bool, error) {
+	s.CalledWith = []interface{}{document}
+	return true, nil
+}
+
+func (s *AssociateServiceStub) CreateRoster(p associate.RosterParams) (associate.Roster, error) {
+	s.CalledWith = []interface{}{p.Name, len(p.Members)}
+	if p.Name == "ERROR" {
+		return associate.Roster{}, errors.New("A ERROR")
+	}
+	if len(p.Members) == 0 {
+		return associate.Roster{}, associate.ErrBadRoster
+	}
+	return associate.Roster{
+		ID:       "36df597d-a3b7-45cd-b65a-439c0900649e",
+		Name:     p.Name,
+		Members:  p.Members,
+		Size:     len(p.Members),
+		Creation: time.Now(),
+	}, nil
+}
+
+func (s *AssociateServiceStub) FindRoster(id string) (associate.Roster, error) {
+	s.CalledWith = []interface{}{id}
+	if id == "notFound" {
+		return associate.Roster{}, associate.ErrRosterNotFound
+	}
+	return associate.Roster{
+		ID:       id,
+		Members:  []associate.Member{{AssociateID: "A-1", Document: "52998224725"}},
+		Size:     1,
+		Creation: time.Now(),
+	}, nil
+}
+
+func associateRequest(method, id string, body interface{}) *http.Request {
+	requestBody, _ := json.Marshal(body)
+	request, _ := http.NewRequest(method, "/associate/"+id, bytes.NewBuffer(requestBody))
+	return WithPathParams(request, map[string]string{"associateID": id})
+}
+
+func TestPOSTAssociate(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewAssociateHandler(&associateService)
+	t.Run("Should return 201 with the created associate", func(t *testing.T) {
+		request := associateRequest(http.MethodPost, "", HTTPAssociateReq{
+			ID:       "A-1",
+			Document: "52998224725",
+			Name:     "name",
+		})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		var got HTTPAssociateRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if got.ID != "A-1" || got.Document != "52998224725" || !got.Active {
+			t.Errorf("unexpected associate %v", got)
+		}
+	})
+	t.Run("Should call the CreateAssociate with the correct params", func(t *testing.T) {
+		request := associateRequest(http.MethodPost, "", HTTPAssociateReq{
+			ID:       "A-1",
+			Document: "529.982.247-25",
+			Name:     "name",
+			Weight:   12,
+		})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, associateService.CalledWith, "A-1")
+		assertInsideSlice(t, associateService.CalledWith, "529.982.247-25")
+		assertInsideSlice(t, associateService.CalledWith, "name")
+		assertInsideSlice(t, associateService.CalledWith, 12)
+	})
+	t.Run("Should return 400 on invalid documents", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Post(response, associateRequest(http.MethodPost, "", HTTPAssociateReq{ID: "A-1", Document: "invalid"}))
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("Should return 409 on duplicates", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Post(response, associateRequest(http.MethodPost, "", HTTPAssociateReq{ID: "duplicated", Document: "52998224725"}))
+
+		assertStatus(t, response.Code, http.StatusConflict)
+		assertInsideJSON(t, response.Body, "message", associate.ErrDuplicateAssociate.Error())
+	})
+	t.Run("Should return 400 on malformed bodies", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/associate", bytes.NewBufferString(`{"id":}`))
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("Should return 500 on unknown errors", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Post(response, associateRequest(http.MethodPost, "", HTTPAssociateReq{ID: "ERROR"}))
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+}
+
+func TestGETAssociate(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewAssociateHandler(&associateService)
+	t.Run("Should return 200 with the associate of the path", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Get(response, associateRequest(http.MethodGet, "A-1", nil))
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, associateService.CalledWith, "A-1")
+	})
+	t.Run("Should return 404 if the associate was not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Get(response, associateRequest(http.MethodGet, "notFound", nil))
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
+
+func TestPUTAssociate(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewAssociateHandler(&associateService)
+	t.Run("Should update the associate of the path", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Put(response, associateRequest(http.MethodPut, "A-1", HTTPAssociateReq{
+			Document: "52998224725",
+			Name:     "new name",
+		}))
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, associateService.CalledWith, "A-1")
+		assertInsideSlice(t, associateService.CalledWith, "new name")
+	})
+	t.Run("Should return 404 if the associate was not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Put(response, associateRequest(http.MethodPut, "notFound", HTTPAssociateReq{}))
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
+
+func TestDELETEAssociate(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewAssociateHandler(&associateService)
+	t.Run("Should return the deactivated associate", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Delete(response, associateRequest(http.MethodDelete, "A-1", nil))
+
+		assertStatus(t, response.Code, http.StatusOK)
+		var got HTTPAssociateRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if got.Active {
+			t.Errorf("want an inactive associate, got %v", got)
+		}
+	})
+	t.Run("Should return 404 if the associate was not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		h.Delete(response, associateRequest(http.MethodDelete, "notFound", nil))
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
+
+func TestImportAssociates(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewAssociateHandler(&associateService)
+	importCSV := func(csv string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		request, _ := http.NewRequest(http.MethodPost, "/associate/import", bytes.NewBufferString(csv))
+		response := httptest.NewRecorder()
+
+		h.Import(response, request)
+
+		res := map[string]interface{}{}
+		extractJSON(response.Body, res)
+		return response, res
+	}
+	t.Run("Should report each row and the totals", func(t *testing.T) {
+		response, res := importCSV("id,document\nA-1,52998224725\nA-2,invalid\nduplicated,11222333000181\n")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		rows := res["rows"].([]interface{})
+		if len(rows) != 3 {
+			t.Fatalf("want 3 rows, got %v", rows)
+		}
+		first := rows[0].(map[string]interface{})
+		if first["row"] != 2.0 || first["id"] != "A-1" || first["accepted"] != true {
+			t.Errorf("unexpected first row %v", first)
+		}
+		second := rows[1].(map[string]interface{})
+		if second["accepted"] != false || second["reason"] != vote.ErrInvalidDocument.Error() {
+			t.Errorf("unexpected second row %v", second)
+		}
+		summary := res["summary"].(map[string]interface{})
+		if summary["accepted"] != 1.0 || summary["rejected"] != 2.0 {
+			t.Errorf("unexpected summary %v", summary)
+		}
+	})
+	t.Run("Should return an empty report for empty files", func(t *testing.T) {
+		response, res := importCSV("")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		if rows := res["rows"].([]interface{}); len(rows) != 0 {
+			t.Errorf("want no rows, got %v", rows)
+		}
+	})
+	t.Run("Should return 500 if the import fails before any row", func(t *testing.T) {
+		response, _ := importCSV("ERROR,52998224725\n")
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+	t.Run("Should inform in the summary an import that failed midway", func(t *testing.T) {
+		response, res := importCSV("A-1,52998224725\nERROR,52998224725\nA-3,52998224725\n")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		if rows := res["rows"].([]interface{}); len(rows) != 1 {
+			t.Errorf("want the rows before the error, got %v", rows)
+		}
+		summary := res["summary"].(map[string]interface{})
+		if summary["error"] == nil {
+			t.Errorf("want an error in the summary, got %v", summary)
+		}
+	})
+}
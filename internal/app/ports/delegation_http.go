@@ -0,0 +1,80 @@
+package ports
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+type delegationHandler struct {
+	service vote.Service
+}
+
+// DelegationHandler describes a http handler interface
+type DelegationHandler interface {
+	Post(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+// NewDelegationHandler creates a new http delegation handler
+func NewDelegationHandler(s vote.Service) DelegationHandler {
+	return &delegationHandler{
+		service: s,
+	}
+}
+
+// Post http translator
+func (h *delegationHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var o HTTPCreateDelegationReq
+	err := decodeJSONBody(r, &o, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	d, err := h.service.CreateDelegation(vote.DelegationParams{
+		GrantorID:       o.GrantorID,
+		GrantorDocument: o.GrantorDocument,
+		ProxyDocument:   o.ProxyDocument,
+		AgendaID:        o.AgendaID,
+		SessionID:       o.SessionID,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newHTTPDelegationRes(d))
+	return
+}
+
+// Get http translator
+func (h *delegationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	d, err := h.service.FindDelegation(PathParam(r, "delegationID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPDelegationRes(d))
+	return
+}
+
+func newHTTPDelegationRes(d vote.Delegation) HTTPDelegationRes {
+	return HTTPDelegationRes{
+		ID:              d.ID,
+		GrantorID:       d.GrantorID,
+		GrantorDocument: d.GrantorDocument,
+		ProxyDocument:   d.ProxyDocument,
+		Scope:           d.Scope,
+		AgendaID:        d.AgendaID,
+		SessionID:       d.SessionID,
+		Creation:        d.Creation.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,94 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+func TestPOSTDelegation(t *testing.T) {
+	voteService := VoteServiceStub{}
+	h := NewDelegationHandler(&voteService)
+	post := func(body interface{}) *httptest.ResponseRecorder {
+		requestBody, _ := json.Marshal(body)
+		request, _ := http.NewRequest(http.MethodPost, "/delegation", bytes.NewBuffer(requestBody))
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+		return response
+	}
+	t.Run("Should return 201 with the created delegation", func(t *testing.T) {
+		response := post(HTTPCreateDelegationReq{
+			GrantorID:       "grantorID",
+			GrantorDocument: "39053344705",
+			ProxyDocument:   "52998224725",
+			AgendaID:        "agendaID",
+			SessionID:       "sessionID",
+		})
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		var got HTTPDelegationRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if got.ID != "delegationID" || got.Scope != vote.ScopeSession || got.SessionID != "sessionID" {
+			t.Errorf("unexpected delegation %v", got)
+		}
+		assertInsideSlice(t, voteService.CalledWith, "grantorID")
+		assertInsideSlice(t, voteService.CalledWith, "52998224725")
+	})
+	t.Run("Should return 400 on delegations without a grantor", func(t *testing.T) {
+		response := post(HTTPCreateDelegationReq{ProxyDocument: "52998224725", AgendaID: "agendaID"})
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrBadDelegation.Error())
+	})
+	t.Run("Should return 400 if the proxy holds too many delegations", func(t *testing.T) {
+		response := post(HTTPCreateDelegationReq{GrantorID: "grantorID", ProxyDocument: "busy", AgendaID: "agendaID"})
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrTooManyProxies.Error())
+	})
+	t.Run("Should return 409 if the vote was already delegated", func(t *testing.T) {
+		response := post(HTTPCreateDelegationReq{GrantorID: "delegated", ProxyDocument: "52998224725", AgendaID: "agendaID"})
+
+		assertStatus(t, response.Code, http.StatusConflict)
+		assertInsideJSON(t, response.Body, "message", vote.ErrDuplicateDelegation.Error())
+	})
+	t.Run("Should return 500 on unknown errors", func(t *testing.T) {
+		response := post(HTTPCreateDelegationReq{GrantorID: "ERROR"})
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+}
+
+func TestGETDelegation(t *testing.T) {
+	voteService := VoteServiceStub{}
+	h := NewDelegationHandler(&voteService)
+	get := func(id string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodGet, "/delegation/"+id, nil)
+		request = WithPathParams(request, map[string]string{"delegationID": id})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		return response
+	}
+	t.Run("Should return the delegation", func(t *testing.T) {
+		response := get("anID")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, voteService.CalledWith, "anID")
+		var got HTTPDelegationRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if got.Scope != vote.ScopeAgenda || got.SessionID != "" {
+			t.Errorf("unexpected delegation %v", got)
+		}
+	})
+	t.Run("Should return 404 if the delegation was not found", func(t *testing.T) {
+		response := get("notFound")
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,67 @@
+package ports
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errBadISODuration = errors.New("Invalid ISO-8601 duration")
+
+// isoDuration matches the ISO-8601 durations with weeks, days, hours,
+// minutes and seconds. Years and months are left out as their length
+// depends on when they are counted from
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+var isoUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// parseISODuration parses an ISO-8601 duration as PT90M or P1DT12H
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, errBadISODuration
+	}
+
+	var total float64
+	for i, unit := range isoUnits {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.Replace(m[i+1], ",", ".", 1), 64)
+		if err != nil {
+			return 0, errBadISODuration
+		}
+		total += v * float64(unit)
+	}
+	if total >= math.MaxInt64 {
+		return 0, errBadISODuration
+	}
+	return time.Duration(total), nil
+}
+
+// maxMinutes is the longest duration in minutes that fits a time.Duration
+const maxMinutes = int64(math.MaxInt64 / time.Minute)
+
+// requestDuration reads a duration informed either in minutes or as an
+// ISO-8601 duration, it is nil when none is informed, so an informed zero
+// is told apart, and not ok when both are informed, the minutes overflow
+// or the ISO-8601 one is malformed
+func requestDuration(minutes *int, iso string) (*time.Duration, bool) {
+	switch {
+	case minutes != nil && iso != "":
+		return nil, false
+	case minutes != nil:
+		if m := int64(*minutes); m > maxMinutes || m < -maxMinutes {
+			return nil, false
+		}
+		d := time.Duration(*minutes) * time.Minute
+		return &d, true
+	case iso != "":
+		d, err := parseISODuration(iso)
+		return &d, err == nil
+	}
+	return nil, true
+}
@@ -0,0 +1,77 @@
+package ports
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"PT90M":      90 * time.Minute,
+		"PT1H30M":    90 * time.Minute,
+		"P1DT12H":    36 * time.Hour,
+		"P2W":        14 * 24 * time.Hour,
+		"PT0.5S":     500 * time.Millisecond,
+		"PT1,5S":     1500 * time.Millisecond,
+		"P1DT1H1M1S": 25*time.Hour + time.Minute + time.Second,
+	}
+	for s, want := range valid {
+		t.Run(s, func(t *testing.T) {
+			got, err := parseISODuration(s)
+
+			if err != nil {
+				t.Errorf("did not want an error, got %v", err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+	for _, s := range []string{"", "P", "PT", "P1DT", "P1Y", "P1M", "PT-1H", "1H", "PT1.5H", "P99999999999999W"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := parseISODuration(s)
+
+			if err != errBadISODuration {
+				t.Errorf("want %v, got %v", errBadISODuration, err)
+			}
+		})
+	}
+}
+
+func TestRequestDuration(t *testing.T) {
+	t.Run("Reads the duration in minutes", func(t *testing.T) {
+		got, ok := requestDuration(minutes(30), "")
+
+		if !ok || got == nil || *got != 30*time.Minute {
+			t.Errorf("want %v, got %v", 30*time.Minute, got)
+		}
+	})
+	t.Run("Reads the ISO-8601 duration", func(t *testing.T) {
+		got, ok := requestDuration(nil, "PT30M")
+
+		if !ok || got == nil || *got != 30*time.Minute {
+			t.Errorf("want %v, got %v", 30*time.Minute, got)
+		}
+	})
+	t.Run("Tells an informed zero apart from none", func(t *testing.T) {
+		zero, ok := requestDuration(minutes(0), "")
+		if !ok || zero == nil || *zero != 0 {
+			t.Errorf("want a zero duration, got %v", zero)
+		}
+
+		none, ok := requestDuration(nil, "")
+		if !ok || none != nil {
+			t.Errorf("want no duration, got %v", none)
+		}
+	})
+	t.Run("Is not ok when both are informed", func(t *testing.T) {
+		if _, ok := requestDuration(minutes(30), "PT30M"); ok {
+			t.Errorf("want not ok, got ok")
+		}
+	})
+	t.Run("Is not ok when the minutes overflow", func(t *testing.T) {
+		if _, ok := requestDuration(minutes(int(maxMinutes+1)), ""); ok {
+			t.Errorf("want not ok, got ok")
+		}
+	})
+}
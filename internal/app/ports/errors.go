@@ -0,0 +1,90 @@
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+// errorStatuses maps the domain errors to the http status they are
+// answered with, errors are matched with errors.Is so wrapped errors
+// are answered the same way
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	{associate.ErrAssociateNotFound, http.StatusNotFound},
+	{associate.ErrDuplicateAssociate, http.StatusConflict},
+	{associate.ErrBadAssociate, http.StatusBadRequest},
+	{associate.ErrRosterNotFound, http.StatusNotFound},
+	{associate.ErrBadRoster, http.StatusBadRequest},
+	{associate.ErrUnknownMember, http.StatusBadRequest},
+	{associate.ErrMismatchedMember, http.StatusBadRequest},
+	{agenda.ErrAgendaNotFound, http.StatusNotFound},
+	{agenda.ErrTooFewOptions, http.StatusBadRequest},
+	{agenda.ErrBadOptionFormat, http.StatusBadRequest},
+	{agenda.ErrDuplicateOption, http.StatusBadRequest},
+	{agenda.ErrReservedOption, http.StatusBadRequest},
+	{agenda.ErrBadFilter, http.StatusBadRequest},
+	{agenda.ErrBadCursor, http.StatusBadRequest},
+	{session.ErrSessionNotFound, http.StatusNotFound},
+	{session.ErrBadDecisionRule, http.StatusBadRequest},
+	{session.ErrSecretVoteChanges, http.StatusBadRequest},
+	{session.ErrBadMethod, http.StatusBadRequest},
+	{session.ErrBadApproval, http.StatusBadRequest},
+	{session.ErrBadStart, http.StatusBadRequest},
+	{session.ErrBadDuration, http.StatusBadRequest},
+	{session.ErrBadTransition, http.StatusConflict},
+	{session.ErrBadExtension, http.StatusBadRequest},
+	{session.ErrReopenWindowExpired, http.StatusConflict},
+	{vote.ErrDuplicateVote, http.StatusConflict},
+	{vote.ErrBadVoteFormat, http.StatusBadRequest},
+	{vote.ErrSessionExpired, http.StatusBadRequest},
+	{vote.ErrSessionNotOpen, http.StatusBadRequest},
+	{vote.ErrSessionCancelled, http.StatusBadRequest},
+	{vote.ErrNotAbleToVote, http.StatusBadRequest},
+	{vote.ErrNotInRoster, http.StatusBadRequest},
+	{vote.ErrVoteNotFound, http.StatusNotFound},
+	{vote.ErrVoteChangesNotAllowed, http.StatusBadRequest},
+	{vote.ErrDelegationNotFound, http.StatusNotFound},
+	{vote.ErrBadDelegation, http.StatusBadRequest},
+	{vote.ErrDuplicateDelegation, http.StatusConflict},
+	{vote.ErrTooManyProxies, http.StatusBadRequest},
+	{vote.ErrNotAProxy, http.StatusBadRequest},
+	{vote.ErrBadRanking, http.StatusBadRequest},
+	{vote.ErrBadSelections, http.StatusBadRequest},
+	{vote.ErrInvalidDocument, http.StatusBadRequest},
+	{vote.ErrValidatorUnavailable, http.StatusServiceUnavailable},
+}
+
+// writeError answers the request with the status mapped to the error,
+// unknown errors are answered as internal server errors without leaking
+// their message
+func writeError(w http.ResponseWriter, err error) {
+	var mr *malformedRequest
+	if errors.As(err, &mr) {
+		writeErrorMessage(w, mr.status, mr.msg)
+		return
+	}
+
+	for _, m := range errorStatuses {
+		if errors.Is(err, m.err) {
+			writeErrorMessage(w, m.status, m.err.Error())
+			return
+		}
+	}
+	internalServerError(w)
+}
+
+func writeErrorMessage(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(HTTPError{
+		Message: msg,
+	})
+}
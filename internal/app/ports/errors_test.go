@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
+)
+
+func TestWriteError(t *testing.T) {
+	t.Run("Should answer with the status mapped to the error", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		writeError(response, vote.ErrNotAbleToVote)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrNotAbleToVote.Error())
+	})
+	t.Run("Should match wrapped errors without leaking the cause", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		writeError(response, fmt.Errorf("%w: a driver message", session.ErrSessionNotFound))
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+		assertInsideJSON(t, response.Body, "message", session.ErrSessionNotFound.Error())
+	})
+	t.Run("Should answer malformed requests with their own status", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		writeError(response, &malformedRequest{status: http.StatusRequestEntityTooLarge, msg: "too large"})
+
+		assertStatus(t, response.Code, http.StatusRequestEntityTooLarge)
+		assertInsideJSON(t, response.Body, "message", "too large")
+	})
+	t.Run("Should answer unknown errors as internal server errors", func(t *testing.T) {
+		response := httptest.NewRecorder()
+
+		writeError(response, errors.New("a secret message"))
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+		assertInsideJSON(t, response.Body, "message", "There was an unexpected error")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 type malformedRequest struct {
@@ -32,8 +33,11 @@ func decodeJSONBody(r *http.Request, dst interface{}, ignoreEmpty bool) error {
 	if err != nil {
 		var syntaxError *json.SyntaxError
 		var unmarshalTypeError *json.UnmarshalTypeError
+		var parseError *time.ParseError
 
 		switch {
+		case errors.Is(err, io.EOF) && ignoreEmpty:
+			return nil
 		case errors.Is(err, io.EOF):
 			msg := "Invalid: Empty body"
 			return &malformedRequest{status: http.StatusBadRequest, msg: msg}
@@ -46,6 +50,9 @@ func decodeJSONBody(r *http.Request, dst interface{}, ignoreEmpty bool) error {
 				unmarshalTypeError.Field,
 				unmarshalTypeError.Offset)
 			return &malformedRequest{status: http.StatusBadRequest, msg: msg}
+		case errors.As(err, &parseError):
+			msg := fmt.Sprintf("Request body contains invalid time %q, times must follow RFC 3339", parseError.Value)
+			return &malformedRequest{status: http.StatusBadRequest, msg: msg}
 		}
 		return err
 	}
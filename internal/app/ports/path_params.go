@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"net/http"
+)
+
+type pathParamsKey struct{}
+
+// WithPathParams returns a copy of the request carrying the path params
+// extracted by the router
+func WithPathParams(r *http.Request, params map[string]string) *http.Request {
+	ctx := context.WithValue(r.Context(), pathParamsKey{}, params)
+	return r.WithContext(ctx)
+}
+
+// PathParam returns a named path param of the request, empty if the
+// router did not extract it
+func PathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(pathParamsKey{}).(map[string]string)
+	return params[name]
+}
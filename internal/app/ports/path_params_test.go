@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPathParam(t *testing.T) {
+	t.Run("Should return the param set by the router", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "anID"})
+
+		got := PathParam(request, "agendaID")
+
+		if got != "anID" {
+			t.Errorf("got %q, want %q", got, "anID")
+		}
+	})
+	t.Run("Should return an empty string without params", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID", nil)
+
+		got := PathParam(request, "agendaID")
+
+		if got != "" {
+			t.Errorf("got %q, want an empty string", got)
+		}
+	})
+}
@@ -11,45 +11,253 @@ type HTTPError struct {
 	Message string `json:"message"`
 }
 
+// HTTPOption json http representation of an agenda ballot option
+type HTTPOption struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
 // HTTPCreateAgendaReq json http representation of a create agenda request
 type HTTPCreateAgendaReq struct {
-	Description string `json:"description"`
+	Description string       `json:"description"`
+	Options     []HTTPOption `json:"options,omitempty"`
 }
 
 // HTTPCreateAgendaRes json http representation of a create agenda response
 type HTTPCreateAgendaRes struct {
+	ID          string       `json:"id"`
+	Description string       `json:"description"`
+	Options     []HTTPOption `json:"options"`
+}
+
+// HTTPAgendaSummary json http representation of an agenda inside a listing
+type HTTPAgendaSummary struct {
 	ID          string `json:"id"`
 	Description string `json:"description"`
+	Status      string `json:"status"`
+	Creation    string `json:"creation"`
+}
+
+// HTTPListAgendasRes json http representation of a page of agendas
+type HTTPListAgendasRes struct {
+	Agendas    []HTTPAgendaSummary `json:"agendas"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
+
+// HTTPDecisionRule json http representation of a session decision rule
+type HTTPDecisionRule struct {
+	Majority       string `json:"majority"`
+	Basis          string `json:"basis"`
+	QuorumVoters   int    `json:"quorumVoters"`
+	QuorumPercent  int    `json:"quorumPercent"`
+	EligibleVoters int    `json:"eligibleVoters"`
 }
 
-// HTTPCreateSessionReq json http representation of a create session request
+// HTTPCreateSessionReq json http representation of a create session request,
+// it lasts for the duration, in minutes or ISO-8601, or until closesAt
 type HTTPCreateSessionReq struct {
-	Duration time.Duration `json:"durationInMinutes"`
+	OpensAt       time.Time        `json:"opensAt"`
+	Duration      *int             `json:"durationInMinutes,omitempty"`
+	ISODuration   string           `json:"duration,omitempty"`
+	ClosesAt      time.Time        `json:"closesAt"`
+	Rule          HTTPDecisionRule `json:"rule"`
+	RosterID      string           `json:"rosterID,omitempty"`
+	Secret        bool             `json:"secret"`
+	VoteChanges   bool             `json:"voteChanges"`
+	Method        string           `json:"method,omitempty"`
+	MaxSelections int              `json:"maxSelections,omitempty"`
+	Seats         int              `json:"seats,omitempty"`
 }
 
 // HTTPCreateSessionRes json http representation of a create session response
 type HTTPCreateSessionRes struct {
-	ID             string `json:"id"`
-	OriginalAgenda string `json:"originalAgenda"`
-	Expiration     string `json:"expiration"`
+	ID             string           `json:"id"`
+	OriginalAgenda string           `json:"originalAgenda"`
+	State          string           `json:"state,omitempty"`
+	OpensAt        string           `json:"opensAt"`
+	ClosesAt       string           `json:"closesAt"`
+	Expiration     string           `json:"expiration"`
+	Rule           HTTPDecisionRule `json:"rule"`
+	RosterID       string           `json:"rosterID,omitempty"`
+	Secret         bool             `json:"secret"`
+	VoteChanges    bool             `json:"voteChanges"`
+	Method         string           `json:"method"`
+	MaxSelections  int              `json:"maxSelections,omitempty"`
+	Seats          int              `json:"seats,omitempty"`
 }
 
-// HTTPCreateVoteReq json http representation of a create vote request
+// HTTPSessionTransitionReq json http representation of an administrative
+// change to a session, the duration, in minutes or ISO-8601, is only
+// informed to extend or reopen it
+type HTTPSessionTransitionReq struct {
+	Duration    *int   `json:"durationInMinutes,omitempty"`
+	ISODuration string `json:"duration,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// HTTPSessionSummary json http representation of a session inside a listing
+type HTTPSessionSummary struct {
+	ID         string `json:"id"`
+	State      string `json:"state"`
+	Creation   string `json:"creation"`
+	OpensAt    string `json:"opensAt"`
+	ClosesAt   string `json:"closesAt"`
+	Expiration string `json:"expiration"`
+}
+
+// HTTPListSessionsRes json http representation of the sessions of an agenda
+type HTTPListSessionsRes struct {
+	Sessions []HTTPSessionSummary `json:"sessions"`
+}
+
+// HTTPCreateVoteReq json http representation of a create vote request,
+// a proxy informs its own document and the ID of the grantor. Votes of
+// ranked sessions inform the ranking and votes of approval sessions the
+// selections instead of the vote
 type HTTPCreateVoteReq struct {
-	AssociateID string `json:"associateID"`
-	Document    string `json:"document"`
-	Vote        string `json:"vote"`
+	AssociateID string   `json:"associateID"`
+	Document    string   `json:"document"`
+	Vote        string   `json:"vote"`
+	GrantorID   string   `json:"grantorID,omitempty"`
+	Ranking     []string `json:"ranking,omitempty"`
+	Selections  []string `json:"selections,omitempty"`
+}
+
+// HTTPChangeVoteReq json http representation of a change or retract vote
+// request, the document must be the one that voted. Changes of ranked and
+// approval sessions inform the ranking or the selections instead of the vote
+type HTTPChangeVoteReq struct {
+	Document   string   `json:"document"`
+	Vote       string   `json:"vote"`
+	Ranking    []string `json:"ranking,omitempty"`
+	Selections []string `json:"selections,omitempty"`
+}
+
+// HTTPOptionCount json http representation of the votes of an option,
+// by headcount and weighted. Delegated is the part cast by proxies
+type HTTPOptionCount struct {
+	ID        string `json:"id"`
+	Votes     int    `json:"votes"`
+	Weight    int    `json:"weight"`
+	Delegated int    `json:"delegated"`
 }
 
 // HTTPResultSessionRes json http representation of a session result response
 type HTTPResultSessionRes struct {
-	ID             string `json:"id"`
-	OriginalAgenda string `json:"originalAgenda"`
-	Closed         bool   `json:"closed"`
+	ID             string   `json:"id"`
+	OriginalAgenda string   `json:"originalAgenda"`
+	Closed         bool     `json:"closed"`
+	Void           bool     `json:"void"`
+	Outcome        string   `json:"outcome"`
+	Winner         string   `json:"winner,omitempty"`
+	Winners        []string `json:"winners,omitempty"`
 	Count          struct {
-		InFavor  int `json:"inFavor"`
-		Angainst int `json:"against"`
+		Options              []HTTPOptionCount `json:"options"`
+		Abstentions          int               `json:"abstentions"`
+		AbstentionWeight     int               `json:"abstentionWeight"`
+		DelegatedAbstentions int               `json:"delegatedAbstentions"`
 	} `json:"count"`
+	Rounds    []HTTPRound       `json:"rounds,omitempty"`
+	Approvals []HTTPOptionCount `json:"approvals,omitempty"`
+	Turnout   HTTPTurnout       `json:"turnout"`
+}
+
+// HTTPRound json http representation of an instant runoff round
+type HTTPRound struct {
+	Options    []HTTPOptionCount `json:"options"`
+	Eliminated []string          `json:"eliminated"`
+}
+
+// HTTPTurnout json http representation of a session turnout, eligible is
+// zero when the number of eligible associates is unknown
+type HTTPTurnout struct {
+	Voters    int     `json:"voters"`
+	Direct    int     `json:"direct"`
+	Delegated int     `json:"delegated"`
+	Weight    int     `json:"weight"`
+	Eligible  int     `json:"eligible"`
+	Percent   float64 `json:"percent"`
+}
+
+// HTTPAssociateReq json http representation of a create or update associate
+// request, the ID is taken from the path on updates
+type HTTPAssociateReq struct {
+	ID       string `json:"id,omitempty"`
+	Document string `json:"document"`
+	Name     string `json:"name"`
+	Weight   int    `json:"weight,omitempty"`
+}
+
+// HTTPAssociateRes json http representation of an associate
+type HTTPAssociateRes struct {
+	ID       string `json:"id"`
+	Document string `json:"document"`
+	Name     string `json:"name"`
+	Weight   int    `json:"weight"`
+	Active   bool   `json:"active"`
+	Creation string `json:"creation"`
+	Update   string `json:"update"`
+}
+
+// HTTPRosterMember json http representation of a roster member
+type HTTPRosterMember struct {
+	AssociateID string `json:"associateID,omitempty"`
+	Document    string `json:"document,omitempty"`
+	Weight      int    `json:"weight,omitempty"`
+}
+
+// HTTPCreateRosterReq json http representation of a create roster request
+type HTTPCreateRosterReq struct {
+	Name    string             `json:"name"`
+	Members []HTTPRosterMember `json:"members"`
+}
+
+// HTTPRosterRes json http representation of a roster
+type HTTPRosterRes struct {
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	Size     int                `json:"size"`
+	Members  []HTTPRosterMember `json:"members"`
+	Creation string             `json:"creation"`
+}
+
+// HTTPCreateDelegationReq json http representation of a create delegation
+// request, the delegation applies to the whole agenda without a session
+type HTTPCreateDelegationReq struct {
+	GrantorID       string `json:"grantorID"`
+	GrantorDocument string `json:"grantorDocument"`
+	ProxyDocument   string `json:"proxyDocument"`
+	AgendaID        string `json:"agendaID"`
+	SessionID       string `json:"sessionID,omitempty"`
+}
+
+// HTTPDelegationRes json http representation of a delegation
+type HTTPDelegationRes struct {
+	ID              string `json:"id"`
+	GrantorID       string `json:"grantorID"`
+	GrantorDocument string `json:"grantorDocument"`
+	ProxyDocument   string `json:"proxyDocument"`
+	Scope           string `json:"scope"`
+	AgendaID        string `json:"agendaID"`
+	SessionID       string `json:"sessionID,omitempty"`
+	Creation        string `json:"creation"`
+}
+
+// HTTPImportRowRes json http representation of an imported csv row
+type HTTPImportRowRes struct {
+	Row      int    `json:"row"`
+	ID       string `json:"id"`
+	Document string `json:"document"`
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// HTTPImportSummaryRes json http representation of the import totals,
+// sent after the rows
+type HTTPImportSummaryRes struct {
+	Accepted int    `json:"accepted"`
+	Rejected int    `json:"rejected"`
+	Error    string `json:"error,omitempty"`
 }
 
 func internalServerError(w http.ResponseWriter) {
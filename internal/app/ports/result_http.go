@@ -3,7 +3,6 @@ package ports
 import (
 	"encoding/json"
 	"net/http"
-	"strings"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
@@ -25,20 +24,11 @@ func NewResultHandler(s session.Service) ResultHandler {
 }
 
 func (h *resultHandler) Get(w http.ResponseWriter, r *http.Request) {
-	sliced := strings.Split(r.URL.Path, "/session/")
-	id := strings.TrimSuffix(sliced[1], "/result")
+	agendaID, id := PathParam(r, "agendaID"), PathParam(r, "sessionID")
 
-	result, err := h.service.Result(id)
+	result, err := h.service.Result(agendaID, id)
 	if err != nil {
-		if err.Error() == "Session not found" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: err.Error(),
-			})
-			return
-		}
-		internalServerError(w)
+		writeError(w, err)
 		return
 	}
 
@@ -48,9 +38,44 @@ func (h *resultHandler) Get(w http.ResponseWriter, r *http.Request) {
 		ID:             result.ID,
 		OriginalAgenda: result.OriginalAgenda,
 		Closed:         result.Closed,
+		Void:           result.Void,
+		Outcome:        result.Outcome,
+		Winner:         result.Winner,
+		Winners:        result.Winners,
+	}
+	responseBody.Count.Options = newHTTPOptionCounts(result.Count.Options)
+	responseBody.Count.Abstentions = result.Count.Abstentions
+	responseBody.Count.AbstentionWeight = result.Count.AbstentionWeight
+	responseBody.Count.DelegatedAbstentions = result.Count.DelegatedAbstentions
+	for _, round := range result.Rounds {
+		eliminated := round.Eliminated
+		if eliminated == nil {
+			eliminated = []string{}
+		}
+		responseBody.Rounds = append(responseBody.Rounds, HTTPRound{
+			Options:    newHTTPOptionCounts(round.Options),
+			Eliminated: eliminated,
+		})
+	}
+	if result.Approvals != nil {
+		responseBody.Approvals = newHTTPOptionCounts(result.Approvals)
+	}
+	responseBody.Turnout = HTTPTurnout{
+		Voters:    result.Count.Turnout(),
+		Direct:    result.Count.Turnout() - result.Count.Delegated(),
+		Delegated: result.Count.Delegated(),
+		Weight:    result.Count.WeightedTurnout(),
+		Eligible:  result.Eligible,
+		Percent:   result.TurnoutPercent(),
 	}
-	responseBody.Count.InFavor = result.Count.InFavor
-	responseBody.Count.Angainst = result.Count.Against
 	json.NewEncoder(w).Encode(&responseBody)
 	return
 }
+
+func newHTTPOptionCounts(options []session.OptionCount) []HTTPOptionCount {
+	counts := make([]HTTPOptionCount, len(options))
+	for i, o := range options {
+		counts[i] = HTTPOptionCount{ID: o.OptionID, Votes: o.Votes, Weight: o.Weight, Delegated: o.Delegated}
+	}
+	return counts
+}
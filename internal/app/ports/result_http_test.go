@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,20 +11,57 @@ import (
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
 
-func (s *SessionServiceStub) Result(id string) (session.Result, error) {
-	s.CalledWith = []interface{}{id}
+func (s *SessionServiceStub) Result(agendaID, id string) (session.Result, error) {
+	s.CalledWith = []interface{}{agendaID, id}
 	if id == "notFound" {
-		return session.Result{}, errors.New("Session not found")
+		return session.Result{}, session.ErrSessionNotFound
 	}
 	if id == "otherError" {
 		return session.Result{}, errors.New("Any error at all")
 	}
+	if id == "cancelled" {
+		return session.Result{ID: id, Closed: true, Void: true, Outcome: session.OutcomeVoid}, nil
+	}
+	if id == "approval" {
+		return session.Result{
+			ID:      id,
+			Closed:  true,
+			Outcome: session.OutcomeElected,
+			Winners: []string{"A", "C"},
+			Approvals: []session.OptionCount{
+				{OptionID: "A", Votes: 5, Weight: 5},
+				{OptionID: "B", Votes: 1, Weight: 1},
+				{OptionID: "C", Votes: 3, Weight: 3},
+			},
+		}, nil
+	}
+	if id == "ranked" {
+		return session.Result{
+			ID:      id,
+			Closed:  true,
+			Outcome: session.OutcomeElected,
+			Winner:  "B",
+			Rounds: []session.Round{
+				{
+					Options:    []session.OptionCount{{OptionID: "A", Votes: 2}, {OptionID: "B", Votes: 2}, {OptionID: "C", Votes: 1}},
+					Eliminated: []string{"C"},
+				},
+				{Options: []session.OptionCount{{OptionID: "A", Votes: 2}, {OptionID: "B", Votes: 3}}},
+			},
+		}, nil
+	}
 
 	return session.Result{
 		ID:             "36df597d-a3b7-45cd-b65a-439c0900649e",
 		OriginalAgenda: "originalAgenda",
 		Closed:         true,
-		Count:          session.Count{InFavor: 10, Against: 12},
+		Outcome:        session.OutcomeRejected,
+		Winner:         "N",
+		Count: session.Count{Options: []session.OptionCount{
+			{OptionID: "S", Votes: 10, Weight: 40},
+			{OptionID: "N", Votes: 12, Weight: 20},
+		}},
+		Eligible: 44,
 	}, nil
 }
 
@@ -32,6 +70,7 @@ func TestGETResult(t *testing.T) {
 	h := NewResultHandler(&sessionService)
 	t.Run("Should return a 200 if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 
 		want := http.StatusOK
@@ -42,9 +81,10 @@ func TestGETResult(t *testing.T) {
 	})
 	t.Run("Should return a Result if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 
-		wants := []string{"id", "originalAgenda", "closed", "count"}
+		wants := []string{"id", "originalAgenda", "closed", "outcome", "count"}
 
 		h.Get(response, request)
 		respMap := map[string]interface{}{}
@@ -56,17 +96,92 @@ func TestGETResult(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Should return the turnout against the eligible associates", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		respMap := map[string]interface{}{}
+		extractJSON(response.Body, respMap)
+
+		turnout := respMap["turnout"].(map[string]interface{})
+		if turnout["voters"] != 22.0 || turnout["eligible"] != 44.0 || turnout["percent"] != 50.0 {
+			t.Errorf("unexpected turnout %v", turnout)
+		}
+	})
+	t.Run("Should return the weighted totals along with the headcount", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		var got HTTPResultSessionRes
+		json.NewDecoder(response.Body).Decode(&got)
+
+		if got.Count.Options[0] != (HTTPOptionCount{ID: "S", Votes: 10, Weight: 40}) || got.Turnout.Weight != 60 {
+			t.Errorf("unexpected weighted totals %v", got)
+		}
+	})
+	t.Run("Should return the runoff rounds of ranked sessions", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/ranked/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "ranked"})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		var got HTTPResultSessionRes
+		json.NewDecoder(response.Body).Decode(&got)
+
+		if len(got.Rounds) != 2 || len(got.Rounds[0].Eliminated) != 1 || got.Rounds[1].Eliminated == nil {
+			t.Errorf("unexpected rounds %v", got.Rounds)
+		}
+		if got.Rounds[1].Options[1] != (HTTPOptionCount{ID: "B", Votes: 3}) || got.Winner != "B" {
+			t.Errorf("unexpected last round %v", got.Rounds[1])
+		}
+	})
+	t.Run("Should return the approvals and winners of approval sessions", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/approval/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "approval"})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		var got HTTPResultSessionRes
+		json.NewDecoder(response.Body).Decode(&got)
+
+		if len(got.Approvals) != 3 || got.Approvals[2] != (HTTPOptionCount{ID: "C", Votes: 3, Weight: 3}) {
+			t.Errorf("unexpected approvals %v", got.Approvals)
+		}
+		if len(got.Winners) != 2 || got.Winners[1] != "C" {
+			t.Errorf("unexpected winners %v", got.Winners)
+		}
+	})
+	t.Run("Should return the void result of cancelled sessions", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/cancelled/result", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "cancelled"})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		var got HTTPResultSessionRes
+		json.NewDecoder(response.Body).Decode(&got)
+
+		if !got.Void || got.Outcome != session.OutcomeVoid {
+			t.Errorf("want a void result, got %v", got)
+		}
+	})
 	t.Run("Should call find Session with the right params", func(t *testing.T) {
 		getRequest, _ := http.NewRequest(http.MethodGet, "/agenda/anotherID/session/anID/result", nil)
+		getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "anotherID", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 		h.Get(response, getRequest)
 
+		assertInsideSlice(t, sessionService.CalledWith, "anotherID")
 		assertInsideSlice(t, sessionService.CalledWith, "anID")
 	})
 	t.Run("If session was not found", func(t *testing.T) {
 		t.Run("Should return a 404", func(t *testing.T) {
 			want := http.StatusNotFound
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/id/session/notFound/result"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "id", "sessionID": "notFound"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -78,6 +193,7 @@ func TestGETResult(t *testing.T) {
 		t.Run("Should return a 500", func(t *testing.T) {
 			want := http.StatusInternalServerError
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/id/session/otherError/result"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "id", "sessionID": "otherError"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -0,0 +1,83 @@
+package ports
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/associate"
+)
+
+type rosterHandler struct {
+	service associate.Service
+}
+
+// RosterHandler describes a http handler interface
+type RosterHandler interface {
+	Post(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+// NewRosterHandler creates a new http roster handler
+func NewRosterHandler(s associate.Service) RosterHandler {
+	return &rosterHandler{
+		service: s,
+	}
+}
+
+// Post http translator
+func (h *rosterHandler) Post(w http.ResponseWriter, r *http.Request) {
+	var o HTTPCreateRosterReq
+	err := decodeJSONBody(r, &o, false)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	members := make([]associate.Member, len(o.Members))
+	for i, m := range o.Members {
+		members[i] = associate.Member{AssociateID: m.AssociateID, Document: m.Document, Weight: m.Weight}
+	}
+
+	roster, err := h.service.CreateRoster(associate.RosterParams{
+		Name:    o.Name,
+		Members: members,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(newHTTPRosterRes(roster))
+	return
+}
+
+// Get http translator
+func (h *rosterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	roster, err := h.service.FindRoster(PathParam(r, "rosterID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPRosterRes(roster))
+	return
+}
+
+func newHTTPRosterRes(r associate.Roster) HTTPRosterRes {
+	res := HTTPRosterRes{
+		ID:       r.ID,
+		Name:     r.Name,
+		Size:     r.Size,
+		Members:  make([]HTTPRosterMember, len(r.Members)),
+		Creation: r.Creation.Format(time.RFC3339),
+	}
+	for i, m := range r.Members {
+		res.Members[i] = HTTPRosterMember{AssociateID: m.AssociateID, Document: m.Document, Weight: m.Weight}
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package ports
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPOSTRoster(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewRosterHandler(&associateService)
+	post := func(body interface{}) *httptest.ResponseRecorder {
+		requestBody, _ := json.Marshal(body)
+		request, _ := http.NewRequest(http.MethodPost, "/roster", bytes.NewBuffer(requestBody))
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+		return response
+	}
+	t.Run("Should return 201 with the created roster", func(t *testing.T) {
+		response := post(HTTPCreateRosterReq{
+			Name: "Council",
+			Members: []HTTPRosterMember{
+				{AssociateID: "A-1"},
+				{Document: "52998224725"},
+			},
+		})
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		var got HTTPRosterRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if got.Name != "Council" || got.Size != 2 || len(got.Members) != 2 {
+			t.Errorf("unexpected roster %v", got)
+		}
+		assertInsideSlice(t, associateService.CalledWith, "Council")
+	})
+	t.Run("Should return 400 on rosters without members", func(t *testing.T) {
+		response := post(HTTPCreateRosterReq{Name: "Empty"})
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("Should return 500 on unknown errors", func(t *testing.T) {
+		response := post(HTTPCreateRosterReq{Name: "ERROR"})
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+}
+
+func TestGETRoster(t *testing.T) {
+	associateService := AssociateServiceStub{}
+	h := NewRosterHandler(&associateService)
+	get := func(id string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodGet, "/roster/"+id, nil)
+		request = WithPathParams(request, map[string]string{"rosterID": id})
+		response := httptest.NewRecorder()
+
+		h.Get(response, request)
+		return response
+	}
+	t.Run("Should return the roster with its members", func(t *testing.T) {
+		response := get("anID")
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, associateService.CalledWith, "anID")
+		var got HTTPRosterRes
+		json.NewDecoder(response.Body).Decode(&got)
+		if len(got.Members) != 1 || got.Members[0].Document != "52998224725" {
+			t.Errorf("unexpected members %v", got.Members)
+		}
+	})
+	t.Run("Should return 404 if the roster was not found", func(t *testing.T) {
+		response := get("notFound")
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
@@ -2,19 +2,12 @@ package ports
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
 
-type sessionOpts struct {
-	Duration int `json:"durationInMinutes"`
-}
-
 type sessionHandler struct {
 	service session.Service
 }
@@ -23,6 +16,11 @@ type sessionHandler struct {
 type SessionHandler interface {
 	Post(w http.ResponseWriter, r *http.Request)
 	Get(w http.ResponseWriter, r *http.Request)
+	List(w http.ResponseWriter, r *http.Request)
+	Close(w http.ResponseWriter, r *http.Request)
+	Extend(w http.ResponseWriter, r *http.Request)
+	Cancel(w http.ResponseWriter, r *http.Request)
+	Reopen(w http.ResponseWriter, r *http.Request)
 }
 
 // NewSessionHandler creates a new http session handler
@@ -34,69 +32,173 @@ func NewSessionHandler(s session.Service) SessionHandler {
 
 // Post http translator
 func (h *sessionHandler) Post(w http.ResponseWriter, r *http.Request) {
-	trimmed := strings.SplitAfter(r.URL.Path, "/agenda/")
-	originalAgenda := strings.TrimSuffix(trimmed[1], "/session")
+	originalAgenda := PathParam(r, "agendaID")
 
-	var o sessionOpts
+	var o HTTPCreateSessionReq
 	err := decodeJSONBody(r, &o, true)
 	if err != nil {
-		var mr *malformedRequest
-		if errors.As(err, &mr) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(mr.status)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: mr.msg,
-			})
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(HTTPError{
-			Message: fmt.Sprint(err),
-		})
+		writeError(w, err)
+		return
+	}
+	d, ok := requestDuration(o.Duration, o.ISODuration)
+	if !ok {
+		writeError(w, session.ErrBadDuration)
 		return
 	}
 
-	session, err := h.service.CreateSession(originalAgenda, time.Duration(o.Duration*int(time.Minute)))
+	s, err := h.service.CreateSession(originalAgenda, session.Params{
+		Start:    o.OpensAt,
+		Duration: d,
+		Closes:   o.ClosesAt,
+		Rule: session.DecisionRule{
+			Majority:       o.Rule.Majority,
+			Basis:          o.Rule.Basis,
+			QuorumVoters:   o.Rule.QuorumVoters,
+			QuorumPercent:  o.Rule.QuorumPercent,
+			EligibleVoters: o.Rule.EligibleVoters,
+		},
+		RosterID:      o.RosterID,
+		Secret:        o.Secret,
+		VoteChanges:   o.VoteChanges,
+		Method:        o.Method,
+		MaxSelections: o.MaxSelections,
+		Seats:         o.Seats,
+	})
 	if err != nil {
-		internalServerError(w)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(HTTPCreateSessionRes{
-		ID:             session.ID,
-		OriginalAgenda: session.OriginalAgenda,
-		Expiration:     session.GetExpiration().Format(time.RFC3339),
-	})
+	json.NewEncoder(w).Encode(newHTTPSessionRes(s))
 	return
 }
 
 func (h *sessionHandler) Get(w http.ResponseWriter, r *http.Request) {
-	sliced := strings.Split(r.URL.Path, "/session/")
-	id := sliced[1]
+	agendaID, id := PathParam(r, "agendaID"), PathParam(r, "sessionID")
 
-	session, err := h.service.FindSession(id)
+	s, err := h.service.FindSession(agendaID, id)
 	if err != nil {
-		if err.Error() == "Session not found" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: "Session not found",
-			})
-			return
-		}
-		internalServerError(w)
+		writeError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(HTTPCreateSessionRes{
-		ID:             session.ID,
-		OriginalAgenda: session.OriginalAgenda,
-		Expiration:     session.GetExpiration().Format(time.RFC3339),
-	})
+	json.NewEncoder(w).Encode(newHTTPSessionRes(s))
 	return
 }
+
+// List http translator
+func (h *sessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	originalAgenda := PathParam(r, "agendaID")
+
+	sessions, err := h.service.ListSessions(originalAgenda)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	res := HTTPListSessionsRes{
+		Sessions: make([]HTTPSessionSummary, len(sessions)),
+	}
+	for i, s := range sessions {
+		res.Sessions[i] = HTTPSessionSummary{
+			ID:         s.ID,
+			State:      s.State,
+			Creation:   s.Creation.Format(time.RFC3339),
+			OpensAt:    s.OpensAt().Format(time.RFC3339),
+			ClosesAt:   s.GetExpiration().Format(time.RFC3339),
+			Expiration: s.GetExpiration().Format(time.RFC3339),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+	return
+}
+
+// Close http translator
+func (h *sessionHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, func(agendaID, id string, o HTTPSessionTransitionReq) (session.Session, error) {
+		return h.service.CloseSession(agendaID, id, o.Reason)
+	})
+}
+
+// Extend http translator
+func (h *sessionHandler) Extend(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, func(agendaID, id string, o HTTPSessionTransitionReq) (session.Session, error) {
+		d, ok := requestDuration(o.Duration, o.ISODuration)
+		if !ok || d == nil {
+			return session.Session{}, session.ErrBadExtension
+		}
+		return h.service.ExtendSession(agendaID, id, *d, o.Reason)
+	})
+}
+
+// Cancel http translator
+func (h *sessionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, func(agendaID, id string, o HTTPSessionTransitionReq) (session.Session, error) {
+		return h.service.CancelSession(agendaID, id, o.Reason)
+	})
+}
+
+// Reopen http translator
+func (h *sessionHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.transit(w, r, func(agendaID, id string, o HTTPSessionTransitionReq) (session.Session, error) {
+		d, ok := requestDuration(o.Duration, o.ISODuration)
+		if !ok || d == nil {
+			return session.Session{}, session.ErrBadExtension
+		}
+		return h.service.ReopenSession(agendaID, id, *d, o.Reason)
+	})
+}
+
+// transit decodes an administrative change to a session and answers with
+// the changed session
+func (h *sessionHandler) transit(w http.ResponseWriter, r *http.Request, change func(string, string, HTTPSessionTransitionReq) (session.Session, error)) {
+	agendaID, id := PathParam(r, "agendaID"), PathParam(r, "sessionID")
+
+	var o HTTPSessionTransitionReq
+	err := decodeJSONBody(r, &o, true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s, err := change(agendaID, id, o)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(newHTTPSessionRes(s))
+}
+
+func newHTTPSessionRes(s session.Session) HTTPCreateSessionRes {
+	return HTTPCreateSessionRes{
+		ID:             s.ID,
+		OriginalAgenda: s.OriginalAgenda,
+		State:          s.State,
+		OpensAt:        s.OpensAt().Format(time.RFC3339),
+		ClosesAt:       s.GetExpiration().Format(time.RFC3339),
+		Expiration:     s.GetExpiration().Format(time.RFC3339),
+		Rule: HTTPDecisionRule{
+			Majority:       s.Rule.Majority,
+			Basis:          s.Rule.Basis,
+			QuorumVoters:   s.Rule.QuorumVoters,
+			QuorumPercent:  s.Rule.QuorumPercent,
+			EligibleVoters: s.Rule.EligibleVoters,
+		},
+		RosterID:      s.RosterID,
+		Secret:        s.Secret,
+		VoteChanges:   s.VoteChanges,
+		Method:        s.Method,
+		MaxSelections: s.MaxSelections,
+		Seats:         s.Seats,
+	}
+}
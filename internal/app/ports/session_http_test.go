@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/cesarFuhr/votingAPI/internal/app/domain/agenda"
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/session"
 )
 
@@ -19,23 +21,50 @@ type SessionServiceStub struct {
 	LastDeliveredSession session.Session
 }
 
-func (s *SessionServiceStub) CreateSession(originalAgenda string, duration time.Duration) (session.Session, error) {
-	s.CalledWith = []interface{}{originalAgenda, duration}
+func (s *SessionServiceStub) CreateSession(originalAgenda string, p session.Params) (session.Session, error) {
+	var duration interface{}
+	if p.Duration != nil {
+		duration = *p.Duration
+	}
+	s.CalledWith = []interface{}{originalAgenda, duration, p.Rule, p.RosterID, p.Secret, p.VoteChanges, p.Method, p.MaxSelections, p.Seats, p.Closes}
+	if p.Duration != nil && *p.Duration <= 0 {
+		return session.Session{}, session.ErrBadDuration
+	}
 	if originalAgenda == "ERROR" {
 		return session.Session{}, errors.New("A ERROR")
 	}
+	if originalAgenda == "notFound" {
+		return session.Session{}, agenda.ErrAgendaNotFound
+	}
+	if p.Rule.Majority == "unanimity" {
+		return session.Session{}, session.ErrBadDecisionRule
+	}
+	if p.Method == "borda" {
+		return session.Session{}, session.ErrBadMethod
+	}
+	if p.Seats > 2 {
+		return session.Session{}, session.ErrBadApproval
+	}
+	if !p.Start.IsZero() && p.Start.Before(time.Now()) {
+		return session.Session{}, session.ErrBadStart
+	}
 	return session.Session{
 		ID:             "36df597d-a3b7-45cd-b65a-439c0900649e",
 		OriginalAgenda: originalAgenda,
 		Creation:       time.Now(),
+		Start:          p.Start,
 		Duration:       time.Minute,
+		Secret:         p.Secret,
+		Method:         p.Method,
+		MaxSelections:  p.MaxSelections,
+		Seats:          p.Seats,
 	}, nil
 }
 
-func (s *SessionServiceStub) FindSession(id string) (session.Session, error) {
-	s.CalledWith = []interface{}{id}
+func (s *SessionServiceStub) FindSession(agendaID, id string) (session.Session, error) {
+	s.CalledWith = []interface{}{agendaID, id}
 	if id == "notFound" {
-		return session.Session{}, errors.New("Session not found")
+		return session.Session{}, session.ErrSessionNotFound
 	}
 	if id == "otherError" {
 		return session.Session{}, errors.New("Any error at all")
@@ -50,8 +79,65 @@ func (s *SessionServiceStub) FindSession(id string) (session.Session, error) {
 	return s.LastDeliveredSession, nil
 }
 
+func (s *SessionServiceStub) ListSessions(agendaID string) ([]session.Session, error) {
+	s.CalledWith = []interface{}{agendaID}
+	if agendaID == "ERROR" {
+		return nil, errors.New("A ERROR")
+	}
+	if agendaID == "notFound" {
+		return nil, agenda.ErrAgendaNotFound
+	}
+	return []session.Session{{
+		ID:             "36df597d-a3b7-45cd-b65a-439c0900649e",
+		OriginalAgenda: agendaID,
+		Creation:       time.Now(),
+		Duration:       time.Minute,
+		State:          session.StateOpen,
+	}}, nil
+}
+
+func (s *SessionServiceStub) CloseSession(agendaID, id, reason string) (session.Session, error) {
+	return s.transit(session.CloseAction, agendaID, id, 0, reason)
+}
+
+func (s *SessionServiceStub) ExtendSession(agendaID, id string, d time.Duration, reason string) (session.Session, error) {
+	return s.transit(session.ExtendAction, agendaID, id, d, reason)
+}
+
+func (s *SessionServiceStub) CancelSession(agendaID, id, reason string) (session.Session, error) {
+	return s.transit(session.CancelAction, agendaID, id, 0, reason)
+}
+
+func (s *SessionServiceStub) ReopenSession(agendaID, id string, d time.Duration, reason string) (session.Session, error) {
+	return s.transit(session.ReopenAction, agendaID, id, d, reason)
+}
+
+func (s *SessionServiceStub) transit(action, agendaID, id string, d time.Duration, reason string) (session.Session, error) {
+	s.CalledWith = []interface{}{action, agendaID, id, d, reason}
+	if id == "notFound" {
+		return session.Session{}, session.ErrSessionNotFound
+	}
+	if id == "cancelled" {
+		return session.Session{}, session.ErrBadTransition
+	}
+	if d < 0 {
+		return session.Session{}, session.ErrBadExtension
+	}
+	return session.Session{
+		ID:             "36df597d-a3b7-45cd-b65a-439c0900649e",
+		OriginalAgenda: agendaID,
+		Creation:       time.Now(),
+		Duration:       time.Minute + d,
+		State:          session.StateOpen,
+	}, nil
+}
+
+func minutes(m int) *int {
+	return &m
+}
+
 var validSessionReqBody, _ = json.Marshal(HTTPCreateSessionReq{
-	Duration: time.Minute,
+	Duration: minutes(1),
 })
 
 func TestPOSTSession(t *testing.T) {
@@ -59,6 +145,7 @@ func TestPOSTSession(t *testing.T) {
 	h := NewSessionHandler(&sessionService)
 	t.Run("Should return 201 on /session", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(validSessionReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -67,6 +154,7 @@ func TestPOSTSession(t *testing.T) {
 	})
 	t.Run("Should return valid json on /agenda/:id/session", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(validSessionReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -79,9 +167,10 @@ func TestPOSTSession(t *testing.T) {
 	})
 	t.Run("Should return all properties on /session response", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(validSessionReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
 		response := httptest.NewRecorder()
 
-		wants := []string{"id", "originalAgenda", "expiration"}
+		wants := []string{"id", "originalAgenda", "opensAt", "closesAt", "expiration"}
 
 		h.Post(response, request)
 		respMap := map[string]interface{}{}
@@ -96,17 +185,237 @@ func TestPOSTSession(t *testing.T) {
 	t.Run("Should call the CreateSession with the correct params", func(t *testing.T) {
 		duration := time.Minute
 		requestBody, _ := json.Marshal(map[string]interface{}{
-			"durationInMinutes": duration,
+			"durationInMinutes": 1,
 		})
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
 
 		assertInsideSlice(t, sessionService.CalledWith, duration)
 	})
+	t.Run("Should call the CreateSession with the ISO-8601 duration", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"duration": "PT1H30M",
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, sessionService.CalledWith, 90*time.Minute)
+	})
+	t.Run("Should call the CreateSession with the closing time", func(t *testing.T) {
+		closesAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"closesAt": closesAt,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, sessionService.CalledWith, closesAt)
+	})
+	t.Run("Should return a BadRequest if a time is malformed", func(t *testing.T) {
+		for _, field := range []string{"opensAt", "closesAt"} {
+			requestBody, _ := json.Marshal(map[string]interface{}{field: "tomorrow"})
+			request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+			request = WithPathParams(request, map[string]string{"agendaID": "id"})
+			response := httptest.NewRecorder()
+
+			h.Post(response, request)
+
+			assertStatus(t, response.Code, http.StatusBadRequest)
+			assertInsideJSON(t, response.Body, "message", `Request body contains invalid time "tomorrow", times must follow RFC 3339`)
+		}
+	})
+	t.Run("Should call the CreateSession without a duration if none is informed", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer([]byte("{}")))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		if sessionService.CalledWith[1] != nil {
+			t.Errorf("want no duration, got %v", sessionService.CalledWith[1])
+		}
+	})
+	t.Run("Should return a BadRequest if the duration is invalid", func(t *testing.T) {
+		bodies := []map[string]interface{}{
+			{"duration": "1h"},
+			{"duration": "P1M"},
+			{"duration": "PT"},
+			{"duration": "PT1H", "durationInMinutes": 60},
+			{"durationInMinutes": 0},
+			{"duration": "PT0S"},
+			{"durationInMinutes": int64(math.MaxInt64 / time.Minute * 2)},
+		}
+		for _, body := range bodies {
+			requestBody, _ := json.Marshal(body)
+			request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+			request = WithPathParams(request, map[string]string{"agendaID": "id"})
+			response := httptest.NewRecorder()
+
+			h.Post(response, request)
+
+			assertStatus(t, response.Code, http.StatusBadRequest)
+			assertInsideJSON(t, response.Body, "message", session.ErrBadDuration.Error())
+		}
+	})
+	t.Run("Should call the CreateSession with the informed rule", func(t *testing.T) {
+		rule := HTTPDecisionRule{Majority: "absolute", QuorumVoters: 10}
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"rule": rule,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, sessionService.CalledWith, session.DecisionRule{Majority: "absolute", QuorumVoters: 10})
+	})
+	t.Run("Should call the CreateSession with the informed roster", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"rosterID": "aRoster",
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, sessionService.CalledWith, "aRoster")
+	})
+	t.Run("Should create a secret ballot session", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"secret": true,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, sessionService.CalledWith, true)
+		assertInsideJSON(t, response.Body, "secret", true)
+	})
+	t.Run("Should create a ranked session", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"method": session.RankedMethod,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, sessionService.CalledWith, session.RankedMethod)
+		assertInsideJSON(t, response.Body, "method", session.RankedMethod)
+	})
+	t.Run("Should create an approval session", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"method":        session.ApprovalMethod,
+			"maxSelections": 2,
+			"seats":         1,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertInsideSlice(t, sessionService.CalledWith, session.ApprovalMethod)
+		assertInsideSlice(t, sessionService.CalledWith, 2)
+		assertInsideJSON(t, response.Body, "maxSelections", 2.0)
+	})
+	t.Run("Should create a scheduled session", func(t *testing.T) {
+		opensAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"opensAt": opensAt,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideJSON(t, response.Body, "opensAt", opensAt.Format(time.RFC3339))
+	})
+	t.Run("Should return a BadRequest if the session starts in the past", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"opensAt": time.Now().Add(-time.Hour),
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadStart.Error())
+	})
+	t.Run("Should return a BadRequest if the approval seats are invalid", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"method": session.ApprovalMethod,
+			"seats":  5,
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadApproval.Error())
+	})
+	t.Run("Should return a BadRequest if the method is unknown", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"method": "borda",
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadMethod.Error())
+	})
+	t.Run("Should return a BadRequest if the rule is invalid", func(t *testing.T) {
+		requestBody, _ := json.Marshal(map[string]interface{}{
+			"rule": HTTPDecisionRule{Majority: "unanimity"},
+		})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadDecisionRule.Error())
+	})
+	t.Run("Should return a NotFound if the agenda does not exist", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/notFound/session", bytes.NewBuffer(validSessionReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "notFound"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+		assertInsideJSON(t, response.Body, "message", agenda.ErrAgendaNotFound.Error())
+	})
 	t.Run("Should return a internal server error if there was an error creating an session", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/ERROR/session", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "ERROR"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -121,6 +430,7 @@ func TestGETSession(t *testing.T) {
 	h := NewSessionHandler(&sessionService)
 	t.Run("Should return a 200 if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 
 		want := http.StatusOK
@@ -131,9 +441,10 @@ func TestGETSession(t *testing.T) {
 	})
 	t.Run("Should return a Session if it was a success", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/agenda/id/session/anID", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 
-		wants := []string{"id", "originalAgenda", "expiration"}
+		wants := []string{"id", "originalAgenda", "opensAt", "closesAt", "expiration"}
 
 		h.Get(response, request)
 		respMap := map[string]interface{}{}
@@ -147,15 +458,18 @@ func TestGETSession(t *testing.T) {
 	})
 	t.Run("Should call find Session with the right params", func(t *testing.T) {
 		getRequest, _ := http.NewRequest(http.MethodGet, "/agenda/anotherID/session/anID", nil)
+		getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "anotherID", "sessionID": "anID"})
 		response := httptest.NewRecorder()
 		h.Get(response, getRequest)
 
+		assertInsideSlice(t, sessionService.CalledWith, "anotherID")
 		assertInsideSlice(t, sessionService.CalledWith, "anID")
 	})
 	t.Run("If session was not found", func(t *testing.T) {
 		t.Run("Should return a 404", func(t *testing.T) {
 			want := http.StatusNotFound
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/id/session/notFound"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "id", "sessionID": "notFound"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -167,6 +481,7 @@ func TestGETSession(t *testing.T) {
 		t.Run("Should return a 500", func(t *testing.T) {
 			want := http.StatusInternalServerError
 			getRequest, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/agenda/id/session/otherError"), nil)
+			getRequest = WithPathParams(getRequest, map[string]string{"agendaID": "id", "sessionID": "otherError"})
 			response := httptest.NewRecorder()
 			h.Get(response, getRequest)
 
@@ -175,3 +490,130 @@ func TestGETSession(t *testing.T) {
 		})
 	})
 }
+
+func TestLISTSession(t *testing.T) {
+	sessionService := SessionServiceStub{}
+	h := NewSessionHandler(&sessionService)
+	t.Run("Should call ListSessions with the agenda in the path", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID/session", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "anID"})
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, "anID")
+	})
+	t.Run("Should return the sessions with their state", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/anID/session", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "anID"})
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		var got HTTPListSessionsRes
+		json.NewDecoder(response.Body).Decode(&got)
+
+		if len(got.Sessions) != 1 || got.Sessions[0].State != session.StateOpen {
+			t.Errorf("want one %s session, got %v", session.StateOpen, got.Sessions)
+		}
+	})
+	t.Run("Should return a NotFound if the agenda does not exist", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/notFound/session", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "notFound"})
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+	t.Run("Should return a 500 if there was an error", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/agenda/ERROR/session", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "ERROR"})
+		response := httptest.NewRecorder()
+
+		h.List(response, request)
+
+		assertStatus(t, response.Code, http.StatusInternalServerError)
+	})
+}
+
+func TestSessionTransitions(t *testing.T) {
+	sessionService := SessionServiceStub{}
+	h := NewSessionHandler(&sessionService)
+	transit := func(handler http.HandlerFunc, id string, body interface{}) *httptest.ResponseRecorder {
+		requestBody, _ := json.Marshal(body)
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/"+id, bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": id})
+		response := httptest.NewRecorder()
+		handler(response, request)
+		return response
+	}
+	t.Run("Should close the session with the informed reason", func(t *testing.T) {
+		response := transit(h.Close, "anID", HTTPSessionTransitionReq{Reason: "a reason"})
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, session.CloseAction)
+		assertInsideSlice(t, sessionService.CalledWith, "a reason")
+	})
+	t.Run("Should extend the session for the informed minutes", func(t *testing.T) {
+		response := transit(h.Extend, "anID", HTTPSessionTransitionReq{Duration: minutes(30)})
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, session.ExtendAction)
+		assertInsideSlice(t, sessionService.CalledWith, 30*time.Minute)
+		assertInsideJSON(t, response.Body, "state", session.StateOpen)
+	})
+	t.Run("Should extend the session for the informed ISO-8601 duration", func(t *testing.T) {
+		response := transit(h.Extend, "anID", HTTPSessionTransitionReq{ISODuration: "PT2H"})
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, 2*time.Hour)
+	})
+	t.Run("Should return a BadRequest if the ISO-8601 duration is malformed", func(t *testing.T) {
+		response := transit(h.Extend, "anID", HTTPSessionTransitionReq{ISODuration: "2 hours"})
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadExtension.Error())
+	})
+	t.Run("Should cancel the session", func(t *testing.T) {
+		response := transit(h.Cancel, "anID", HTTPSessionTransitionReq{})
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, session.CancelAction)
+	})
+	t.Run("Should cancel the session without a body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/agenda/id/session/anID/cancel", nil)
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "anID"})
+		response := httptest.NewRecorder()
+
+		h.Cancel(response, request)
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, session.CancelAction)
+	})
+	t.Run("Should reopen the session for the informed minutes", func(t *testing.T) {
+		response := transit(h.Reopen, "anID", HTTPSessionTransitionReq{Duration: minutes(10)})
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, sessionService.CalledWith, session.ReopenAction)
+		assertInsideSlice(t, sessionService.CalledWith, 10*time.Minute)
+	})
+	t.Run("Should return a Conflict if the session state does not allow the action", func(t *testing.T) {
+		response := transit(h.Reopen, "cancelled", HTTPSessionTransitionReq{Duration: minutes(10)})
+
+		assertStatus(t, response.Code, http.StatusConflict)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadTransition.Error())
+	})
+	t.Run("Should return a BadRequest if the extension is invalid", func(t *testing.T) {
+		response := transit(h.Extend, "anID", HTTPSessionTransitionReq{Duration: minutes(-5)})
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", session.ErrBadExtension.Error())
+	})
+	t.Run("Should return a NotFound if the session does not exist", func(t *testing.T) {
+		response := transit(h.Close, "notFound", HTTPSessionTransitionReq{})
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
+}
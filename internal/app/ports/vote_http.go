@@ -1,11 +1,7 @@
 package ports
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/cesarFuhr/votingAPI/internal/app/domain/vote"
 )
@@ -17,6 +13,8 @@ type voteHandler struct {
 // VoteHandler describes a http handler interface
 type VoteHandler interface {
 	Post(w http.ResponseWriter, r *http.Request)
+	Put(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
 }
 
 // NewVoteHandler creates a new http vote handler
@@ -26,56 +24,86 @@ func NewVoteHandler(s vote.Service) VoteHandler {
 	}
 }
 
-// Post http translator
+// Post http translator, votes informing a grantor are cast by its proxy
+// and votes informing a ranking or selections belong to ranked or approval
+// sessions
 func (h *voteHandler) Post(w http.ResponseWriter, r *http.Request) {
-	trimmed := strings.SplitAfter(r.URL.Path, "/session/")
-	sessionID := strings.TrimSuffix(trimmed[1], "/vote")
+	agendaID, sessionID := PathParam(r, "agendaID"), PathParam(r, "sessionID")
 
 	var o HTTPCreateVoteReq
 	err := decodeJSONBody(r, &o, true)
 	if err != nil {
-		var mr *malformedRequest
-		if errors.As(err, &mr) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(mr.status)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: mr.msg,
-			})
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(HTTPError{
-			Message: fmt.Sprint(err),
-		})
+		writeError(w, err)
 		return
 	}
 
-	_, err = h.service.CreateVote(o.AssociateID, sessionID, o.Document, o.Vote)
+	switch {
+	case o.GrantorID != "":
+		_, err = h.service.CreateProxyVote(o.Document, o.GrantorID, agendaID, sessionID, vote.Choice{
+			Vote:       o.Vote,
+			Ranking:    o.Ranking,
+			Selections: o.Selections,
+		})
+	case len(o.Ranking) > 0:
+		_, err = h.service.CreateRankedVote(o.AssociateID, agendaID, sessionID, o.Document, o.Ranking)
+	case len(o.Selections) > 0:
+		_, err = h.service.CreateApprovalVote(o.AssociateID, agendaID, sessionID, o.Document, o.Selections)
+	default:
+		_, err = h.service.CreateVote(o.AssociateID, agendaID, sessionID, o.Document, o.Vote)
+	}
 	if err != nil {
-		if err == vote.ErrDuplicateVote ||
-			err == vote.ErrBadVoteFormat ||
-			err == vote.ErrSessionExpired ||
-			err == vote.ErrNotAbleToVote {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: err.Error(),
-			})
-			return
-		}
-		if err.Error() == "Session not found" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(HTTPError{
-				Message: err.Error(),
-			})
-			return
-		}
-		internalServerError(w)
+		writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 	return
 }
+
+// Put http translator
+func (h *voteHandler) Put(w http.ResponseWriter, r *http.Request) {
+	agendaID, sessionID := PathParam(r, "agendaID"), PathParam(r, "sessionID")
+	associateID := PathParam(r, "associateID")
+
+	var o HTTPChangeVoteReq
+	err := decodeJSONBody(r, &o, true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	_, err = h.service.ChangeVote(associateID, agendaID, sessionID, o.Document, vote.Choice{
+		Vote:       o.Vote,
+		Ranking:    o.Ranking,
+		Selections: o.Selections,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return
+}
+
+// Delete http translator
+func (h *voteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	agendaID, sessionID := PathParam(r, "agendaID"), PathParam(r, "sessionID")
+	associateID := PathParam(r, "associateID")
+
+	var o HTTPChangeVoteReq
+	err := decodeJSONBody(r, &o, true)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = h.service.RetractVote(associateID, agendaID, sessionID, o.Document)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
@@ -17,13 +17,13 @@ type VoteServiceStub struct {
 	LastDeliveredVote vote.Vote
 }
 
-func (s *VoteServiceStub) CreateVote(associateID, sessionID, document, value string) (vote.Vote, error) {
-	s.CalledWith = []interface{}{associateID, sessionID, document, value}
+func (s *VoteServiceStub) CreateVote(associateID, agendaID, sessionID, document, value string) (vote.Vote, error) {
+	s.CalledWith = []interface{}{associateID, agendaID, sessionID, document, value}
 	if sessionID == "ERROR" {
 		return vote.Vote{}, errors.New("A ERROR")
 	}
 	if sessionID == "notFound" {
-		return vote.Vote{}, errors.New("Session not found")
+		return vote.Vote{}, vote.ErrSessionNotFound
 	}
 	if sessionID == "duplicateVote" {
 		return vote.Vote{}, vote.ErrDuplicateVote
@@ -31,6 +31,9 @@ func (s *VoteServiceStub) CreateVote(associateID, sessionID, document, value str
 	if sessionID == "expired" {
 		return vote.Vote{}, vote.ErrSessionExpired
 	}
+	if sessionID == "scheduled" {
+		return vote.Vote{}, vote.ErrSessionNotOpen
+	}
 	if sessionID == "badFormat" {
 		return vote.Vote{}, vote.ErrBadVoteFormat
 	}
@@ -46,6 +49,130 @@ func (s *VoteServiceStub) CreateVote(associateID, sessionID, document, value str
 	}, nil
 }
 
+func (s *VoteServiceStub) ChangeVote(associateID, agendaID, sessionID, document string, c vote.Choice) (vote.Vote, error) {
+	s.CalledWith = []interface{}{associateID, agendaID, sessionID, document, c.Vote, len(c.Ranking), len(c.Selections)}
+	if sessionID == "notAllowed" {
+		return vote.Vote{}, vote.ErrVoteChangesNotAllowed
+	}
+	if associateID == "noVote" {
+		return vote.Vote{}, vote.ErrVoteNotFound
+	}
+	return vote.Vote{
+		AssociateID: associateID,
+		SessionID:   sessionID,
+		Document:    document,
+		Vote:        c.Vote,
+		Creation:    time.Now(),
+	}, nil
+}
+
+func (s *VoteServiceStub) RetractVote(associateID, agendaID, sessionID, document string) error {
+	s.CalledWith = []interface{}{associateID, agendaID, sessionID, document}
+	if sessionID == "expired" {
+		return vote.ErrSessionExpired
+	}
+	if associateID == "noVote" {
+		return vote.ErrVoteNotFound
+	}
+	return nil
+}
+
+func (s *VoteServiceStub) CreateDelegation(p vote.DelegationParams) (vote.Delegation, error) {
+	s.CalledWith = []interface{}{p.GrantorID, p.GrantorDocument, p.ProxyDocument, p.AgendaID, p.SessionID}
+	if p.GrantorID == "ERROR" {
+		return vote.Delegation{}, errors.New("A ERROR")
+	}
+	if p.GrantorID == "" {
+		return vote.Delegation{}, vote.ErrBadDelegation
+	}
+	if p.ProxyDocument == "busy" {
+		return vote.Delegation{}, vote.ErrTooManyProxies
+	}
+	if p.GrantorID == "delegated" {
+		return vote.Delegation{}, vote.ErrDuplicateDelegation
+	}
+	scope := vote.ScopeAgenda
+	if p.SessionID != "" {
+		scope = vote.ScopeSession
+	}
+	return vote.Delegation{
+		ID:              "delegationID",
+		GrantorID:       p.GrantorID,
+		GrantorDocument: p.GrantorDocument,
+		ProxyDocument:   p.ProxyDocument,
+		Scope:           scope,
+		AgendaID:        p.AgendaID,
+		SessionID:       p.SessionID,
+		Creation:        time.Now(),
+	}, nil
+}
+
+func (s *VoteServiceStub) FindDelegation(id string) (vote.Delegation, error) {
+	s.CalledWith = []interface{}{id}
+	if id == "notFound" {
+		return vote.Delegation{}, vote.ErrDelegationNotFound
+	}
+	return vote.Delegation{
+		ID:              id,
+		GrantorID:       "grantorID",
+		GrantorDocument: "39053344705",
+		ProxyDocument:   "52998224725",
+		Scope:           vote.ScopeAgenda,
+		AgendaID:        "agendaID",
+		Creation:        time.Now(),
+	}, nil
+}
+
+func (s *VoteServiceStub) CreateProxyVote(proxyDocument, grantorID, agendaID, sessionID string, c vote.Choice) (vote.Vote, error) {
+	s.CalledWith = []interface{}{"proxy", proxyDocument, grantorID, agendaID, sessionID, c.Vote, len(c.Ranking), len(c.Selections)}
+	if grantorID == "notDelegated" {
+		return vote.Vote{}, vote.ErrNotAProxy
+	}
+	return vote.Vote{
+		AssociateID:   grantorID,
+		SessionID:     sessionID,
+		Vote:          c.Vote,
+		ProxyDocument: proxyDocument,
+		Creation:      time.Now(),
+	}, nil
+}
+
+func (s *VoteServiceStub) CreateRankedVote(associateID, agendaID, sessionID, document string, ranking []string) (vote.Vote, error) {
+	s.CalledWith = []interface{}{"ranked", associateID, agendaID, sessionID, document}
+	for _, id := range ranking {
+		s.CalledWith = append(s.CalledWith, id)
+	}
+	if len(ranking) < 2 {
+		return vote.Vote{}, vote.ErrBadRanking
+	}
+	return vote.Vote{
+		AssociateID: associateID,
+		SessionID:   sessionID,
+		Document:    document,
+		Vote:        ranking[0],
+		Ranking:     ranking,
+		Creation:    time.Now(),
+	}, nil
+}
+
+func (s *VoteServiceStub) CreateApprovalVote(associateID, agendaID, sessionID, document string, selections []string) (vote.Vote, error) {
+	s.CalledWith = []interface{}{"approval", associateID, agendaID, sessionID, document}
+	for _, id := range selections {
+		s.CalledWith = append(s.CalledWith, id)
+	}
+	if len(selections) > 2 {
+		return vote.Vote{}, vote.ErrBadSelections
+	}
+	return vote.Vote{
+		AssociateID: associateID,
+		SessionID:   sessionID,
+		Document:    document,
+		Vote:        selections[0],
+		Selections:  selections,
+		Creation:    time.Now(),
+	}, nil
+}
+
 var validVoteReqBody, _ = json.Marshal(HTTPCreateVoteReq{
 	AssociateID: "associateID",
 	Document:    "01212393111",
@@ -57,6 +184,7 @@ func TestPOSTVote(t *testing.T) {
 	h := NewVoteHandler(&voteService)
 	t.Run("Should return 201 on /agenda/id/session/id/vote", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/id/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -64,18 +192,21 @@ func TestPOSTVote(t *testing.T) {
 		assertStatus(t, response.Code, http.StatusCreated)
 	})
 	t.Run("Should call the CreateVote with the correct params", func(t *testing.T) {
-		request, _ := http.NewRequest(http.MethodPost, "agenda/id/session/sessionID/vote", bytes.NewBuffer(validVoteReqBody))
+		request, _ := http.NewRequest(http.MethodPost, "agenda/agendaID/session/sessionID/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
 
 		assertInsideSlice(t, voteService.CalledWith, "associateID")
+		assertInsideSlice(t, voteService.CalledWith, "agendaID")
 		assertInsideSlice(t, voteService.CalledWith, "01212393111")
 		assertInsideSlice(t, voteService.CalledWith, "sessionID")
 		assertInsideSlice(t, voteService.CalledWith, "S")
 	})
 	t.Run("Should return a internal server error if there was an error creating an vote", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/ERROR/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "ERROR"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -83,26 +214,29 @@ func TestPOSTVote(t *testing.T) {
 		assertStatus(t, response.Code, http.StatusInternalServerError)
 		assertInsideJSON(t, response.Body, "message", "There was an unexpected error")
 	})
-	t.Run("Should return a internal server error if there was an error creating an vote", func(t *testing.T) {
+	t.Run("Should return a not found if the session does not exist", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/notFound/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "notFound"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
 
-		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertStatus(t, response.Code, http.StatusNotFound)
 		assertInsideJSON(t, response.Body, "message", "Session not found")
 	})
-	t.Run("Should return a bad request if there was an error creating an vote", func(t *testing.T) {
+	t.Run("Should return a conflict if the vote was already cast", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/duplicateVote/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "duplicateVote"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
 
-		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertStatus(t, response.Code, http.StatusConflict)
 		assertInsideJSON(t, response.Body, "message", vote.ErrDuplicateVote.Error())
 	})
 	t.Run("Should return a bad request if there was an error creating an vote", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/expired/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "expired"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -110,8 +244,19 @@ func TestPOSTVote(t *testing.T) {
 		assertStatus(t, response.Code, http.StatusBadRequest)
 		assertInsideJSON(t, response.Body, "message", vote.ErrSessionExpired.Error())
 	})
+	t.Run("Should return a bad request if the session is not open yet", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/scheduled/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "scheduled"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrSessionNotOpen.Error())
+	})
 	t.Run("Should return a bad request if there was an error creating an vote", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/badFormat/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "badFormat"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -121,6 +266,7 @@ func TestPOSTVote(t *testing.T) {
 	})
 	t.Run("Should return a bad request if there was an error creating an vote", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/notAble/vote", bytes.NewBuffer(validVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "notAble"})
 		response := httptest.NewRecorder()
 
 		h.Post(response, request)
@@ -128,4 +274,177 @@ func TestPOSTVote(t *testing.T) {
 		assertStatus(t, response.Code, http.StatusBadRequest)
 		assertInsideJSON(t, response.Body, "message", vote.ErrNotAbleToVote.Error())
 	})
+	t.Run("Should call the CreateProxyVote when a grantor is informed", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{Document: "52998224725", Vote: "S", GrantorID: "grantorID"})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/agendaID/session/sessionID/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, voteService.CalledWith, "proxy")
+		assertInsideSlice(t, voteService.CalledWith, "52998224725")
+		assertInsideSlice(t, voteService.CalledWith, "grantorID")
+	})
+	t.Run("Should pass the ranking of a vote cast by a proxy", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{Document: "52998224725", GrantorID: "grantorID", Ranking: []string{"N", "S"}})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/agendaID/session/sessionID/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, voteService.CalledWith, "proxy")
+		assertInsideSlice(t, voteService.CalledWith, 2)
+	})
+	t.Run("Should call the CreateRankedVote when a ranking is informed", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{AssociateID: "associateID", Document: "52998224725", Ranking: []string{"N", "S"}})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/agendaID/session/sessionID/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, voteService.CalledWith, "ranked")
+		assertInsideSlice(t, voteService.CalledWith, "N")
+		assertInsideSlice(t, voteService.CalledWith, "S")
+	})
+	t.Run("Should return a bad request if the ranking is incomplete", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{AssociateID: "associateID", Document: "52998224725", Ranking: []string{"N"}})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/id/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrBadRanking.Error())
+	})
+	t.Run("Should call the CreateApprovalVote when selections are informed", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{AssociateID: "associateID", Document: "52998224725", Selections: []string{"S"}})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/agendaID/session/sessionID/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusCreated)
+		assertInsideSlice(t, voteService.CalledWith, "approval")
+		assertInsideSlice(t, voteService.CalledWith, "S")
+	})
+	t.Run("Should return a bad request if there are too many selections", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{AssociateID: "associateID", Document: "52998224725", Selections: []string{"A", "B", "C"}})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/id/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrBadSelections.Error())
+	})
+	t.Run("Should return a bad request if the voter is not the grantor proxy", func(t *testing.T) {
+		body, _ := json.Marshal(HTTPCreateVoteReq{Document: "52998224725", Vote: "S", GrantorID: "notDelegated"})
+		request, _ := http.NewRequest(http.MethodPost, "/agenda/id/session/id/vote", bytes.NewBuffer(body))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id"})
+		response := httptest.NewRecorder()
+
+		h.Post(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrNotAProxy.Error())
+	})
+}
+
+var validChangeVoteReqBody, _ = json.Marshal(HTTPChangeVoteReq{
+	Document: "01212393111",
+	Vote:     "N",
+})
+
+func TestPUTVote(t *testing.T) {
+	voteService := VoteServiceStub{}
+	h := NewVoteHandler(&voteService)
+	t.Run("Should call the ChangeVote with the correct params", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/agenda/agendaID/session/sessionID/vote/associateID", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID", "associateID": "associateID"})
+		response := httptest.NewRecorder()
+
+		h.Put(response, request)
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, voteService.CalledWith, "associateID")
+		assertInsideSlice(t, voteService.CalledWith, "agendaID")
+		assertInsideSlice(t, voteService.CalledWith, "sessionID")
+		assertInsideSlice(t, voteService.CalledWith, "01212393111")
+		assertInsideSlice(t, voteService.CalledWith, "N")
+	})
+	t.Run("Should call the ChangeVote with the informed ranking", func(t *testing.T) {
+		requestBody, _ := json.Marshal(HTTPChangeVoteReq{Document: "01212393111", Ranking: []string{"N", "S", "A"}})
+		request, _ := http.NewRequest(http.MethodPut, "/agenda/agendaID/session/sessionID/vote/associateID", bytes.NewBuffer(requestBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID", "associateID": "associateID"})
+		response := httptest.NewRecorder()
+
+		h.Put(response, request)
+
+		assertStatus(t, response.Code, http.StatusOK)
+		assertInsideSlice(t, voteService.CalledWith, 3)
+	})
+	t.Run("Should return a bad request if the session does not allow vote changes", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/agenda/id/session/notAllowed/vote/associateID", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "notAllowed", "associateID": "associateID"})
+		response := httptest.NewRecorder()
+
+		h.Put(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrVoteChangesNotAllowed.Error())
+	})
+	t.Run("Should return a not found if the associate has not voted", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/agenda/id/session/id/vote/noVote", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id", "associateID": "noVote"})
+		response := httptest.NewRecorder()
+
+		h.Put(response, request)
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+		assertInsideJSON(t, response.Body, "message", vote.ErrVoteNotFound.Error())
+	})
+}
+
+func TestDELETEVote(t *testing.T) {
+	voteService := VoteServiceStub{}
+	h := NewVoteHandler(&voteService)
+	t.Run("Should return 204 and call the RetractVote with the correct params", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodDelete, "/agenda/agendaID/session/sessionID/vote/associateID", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "agendaID", "sessionID": "sessionID", "associateID": "associateID"})
+		response := httptest.NewRecorder()
+
+		h.Delete(response, request)
+
+		assertStatus(t, response.Code, http.StatusNoContent)
+		assertInsideSlice(t, voteService.CalledWith, "associateID")
+		assertInsideSlice(t, voteService.CalledWith, "01212393111")
+	})
+	t.Run("Should return a bad request if the session has expired", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodDelete, "/agenda/id/session/expired/vote/associateID", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "expired", "associateID": "associateID"})
+		response := httptest.NewRecorder()
+
+		h.Delete(response, request)
+
+		assertStatus(t, response.Code, http.StatusBadRequest)
+		assertInsideJSON(t, response.Body, "message", vote.ErrSessionExpired.Error())
+	})
+	t.Run("Should return a not found if the associate has not voted", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodDelete, "/agenda/id/session/id/vote/noVote", bytes.NewBuffer(validChangeVoteReqBody))
+		request = WithPathParams(request, map[string]string{"agendaID": "id", "sessionID": "id", "associateID": "noVote"})
+		response := httptest.NewRecorder()
+
+		h.Delete(response, request)
+
+		assertStatus(t, response.Code, http.StatusNotFound)
+	})
 }
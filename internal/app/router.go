@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cesarFuhr/votingAPI/internal/app/ports"
+)
+
+// middleware decorates a http handler
+type middleware func(http.Handler) http.Handler
+
+var (
+	paramPattern = regexp.MustCompile(`\{(\w+)(:text)?\}`)
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+type router struct {
+	routes []*route
+}
+
+type route struct {
+	template string
+	pattern  *regexp.Regexp
+	params   []param
+	methods  map[string]http.Handler
+}
+
+type param struct {
+	name string
+	text bool
+}
+
+// handle registers a handler for the method and path template, params
+// are declared as {name} and must be UUIDs, or as {name:text} accepting
+// any segment. They reach the handler through ports.PathParam.
+// Middlewares are applied in the informed order
+func (rt *router) handle(method, template string, h http.Handler, mws ...middleware) {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	rt.route(template).methods[method] = h
+}
+
+func (rt *router) route(template string) *route {
+	for _, r := range rt.routes {
+		if r.template == template {
+			return r
+		}
+	}
+
+	var params []param
+	pattern, last := "^", 0
+	for _, loc := range paramPattern.FindAllStringSubmatchIndex(template, -1) {
+		pattern += regexp.QuoteMeta(template[last:loc[0]]) + `([^/]+)`
+		params = append(params, param{name: template[loc[2]:loc[3]], text: loc[4] >= 0})
+		last = loc[1]
+	}
+	pattern += regexp.QuoteMeta(template[last:]) + "$"
+
+	r := &route{
+		template: template,
+		pattern:  regexp.MustCompile(pattern),
+		params:   params,
+		methods:  map[string]http.Handler{},
+	}
+	rt.routes = append(rt.routes, r)
+	return r
+}
+
+// ServeHTTP dispatches to the first route matching both the path and the
+// method, the request is answered as not allowed only when no route
+// matching the path accepts the method
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	allowed := map[string]bool{}
+	for _, route := range rt.routes {
+		values := route.pattern.FindStringSubmatch(r.URL.Path)
+		if values == nil {
+			continue
+		}
+
+		h, ok := route.methods[r.Method]
+		if !ok {
+			for m := range route.methods {
+				allowed[m] = true
+			}
+			continue
+		}
+
+		params := make(map[string]string, len(route.params))
+		for i, p := range route.params {
+			value := values[i+1]
+			if !p.text && !uuidPattern.MatchString(value) {
+				badRequest(w, fmt.Sprintf("Invalid %s, must be a UUID", p.name))
+				return
+			}
+			params[p.name] = value
+		}
+		h.ServeHTTP(w, ports.WithPathParams(r, params))
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", allow(allowed))
+		methodNotAllowed(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func allow(allowed map[string]bool) string {
+	methods := make([]string, 0, len(allowed))
+	for m := range allowed {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	w.Write([]byte{})
+}
+
+func badRequest(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(ports.HTTPError{
+		Message: msg,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v2"
@@ -62,5 +63,24 @@ type Config struct {
 		ConnString string `yaml:"connString" envconfig:"BROKER_CONN_STRING"`
 	} `yaml:"broker"`
 	App struct {
+		ResultInterval   time.Duration `yaml:"resultInterval" envconfig:"APP_RESULT_INTERVAL" default:"10s"`
+		RelayInterval    time.Duration `yaml:"relayInterval" envconfig:"APP_RELAY_INTERVAL" default:"5s"`
+		RelayMaxAttempts int           `yaml:"relayMaxAttempts" envconfig:"APP_RELAY_MAX_ATTEMPTS" default:"10"`
+		MaxProxies       int           `yaml:"maxProxies" envconfig:"APP_MAX_PROXIES" default:"1"`
+		MinDuration      time.Duration `yaml:"minDuration" envconfig:"APP_MIN_DURATION" default:"1m"`
+		MaxDuration      time.Duration `yaml:"maxDuration" envconfig:"APP_MAX_DURATION" default:"720h"`
+		MaxExtension     time.Duration `yaml:"maxExtension" envconfig:"APP_MAX_EXTENSION" default:"24h"`
+		ReopenWindow     time.Duration `yaml:"reopenWindow" envconfig:"APP_REOPEN_WINDOW" default:"24h"`
 	} `yaml:"app"`
+	Validator struct {
+		Source           string        `yaml:"source" envconfig:"VALIDATOR_SOURCE" default:"remote"`
+		BaseURL          string        `yaml:"baseURL" envconfig:"VALIDATOR_BASE_URL" default:"https://user-info.herokuapp.com/users/"`
+		Timeout          time.Duration `yaml:"timeout" envconfig:"VALIDATOR_TIMEOUT" default:"2s"`
+		Retries          int           `yaml:"retries" envconfig:"VALIDATOR_RETRIES" default:"1"`
+		CacheTTL         time.Duration `yaml:"cacheTTL" envconfig:"VALIDATOR_CACHE_TTL" default:"10m"`
+		CacheSize        int           `yaml:"cacheSize" envconfig:"VALIDATOR_CACHE_SIZE" default:"10000"`
+		BreakerThreshold int           `yaml:"breakerThreshold" envconfig:"VALIDATOR_BREAKER_THRESHOLD" default:"5"`
+		BreakerCoolDown  time.Duration `yaml:"breakerCoolDown" envconfig:"VALIDATOR_BREAKER_COOL_DOWN" default:"30s"`
+		FailOpen         bool          `yaml:"failOpen" envconfig:"VALIDATOR_FAIL_OPEN" default:"false"`
+	} `yaml:"validator"`
 }
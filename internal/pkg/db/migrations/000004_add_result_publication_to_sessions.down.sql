@@ -0,0 +1,3 @@
+ALTER TABLE sessions
+  DROP COLUMN IF EXISTS resultPublished,
+  DROP COLUMN IF EXISTS resultPublishedAt
\ No newline at end of file
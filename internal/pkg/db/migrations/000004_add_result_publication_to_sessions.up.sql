@@ -0,0 +1,3 @@
+ALTER TABLE sessions
+  ADD COLUMN IF NOT EXISTS resultPublished BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN IF NOT EXISTS resultPublishedAt TIMESTAMP
\ No newline at end of file
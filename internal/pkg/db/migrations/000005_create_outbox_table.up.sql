@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS outbox(
+  id uuid PRIMARY KEY,
+  eventType VARCHAR(50),
+  payload JSONB,
+  status VARCHAR(20),
+  attempts INT,
+  lastError TEXT,
+  nextAttempt TIMESTAMP,
+  creation TIMESTAMP
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS agenda_options
\ No newline at end of file
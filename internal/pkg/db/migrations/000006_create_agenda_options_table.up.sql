@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS agenda_options(
+  agendaID uuid,
+  id VARCHAR(50),
+  description VARCHAR(500),
+  position INT,
+  PRIMARY KEY (agendaID, id)
+);
+
+INSERT INTO agenda_options (agendaID, id, description, position)
+  SELECT id, 'S', 'Sim', 0 FROM agendas
+  UNION ALL
+  SELECT id, 'N', 'Não', 1 FROM agendas
+  ON CONFLICT DO NOTHING;
+
+ALTER TABLE votes ALTER COLUMN vote TYPE VARCHAR(50)
\ No newline at end of file
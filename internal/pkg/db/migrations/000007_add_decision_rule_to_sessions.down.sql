@@ -0,0 +1,5 @@
+ALTER TABLE sessions
+  DROP COLUMN IF EXISTS majority,
+  DROP COLUMN IF EXISTS quorumVoters,
+  DROP COLUMN IF EXISTS quorumPercent,
+  DROP COLUMN IF EXISTS eligibleVoters
\ No newline at end of file
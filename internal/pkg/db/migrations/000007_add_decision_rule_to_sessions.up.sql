@@ -0,0 +1,5 @@
+ALTER TABLE sessions
+  ADD COLUMN IF NOT EXISTS majority VARCHAR(20) NOT NULL DEFAULT 'simple',
+  ADD COLUMN IF NOT EXISTS quorumVoters INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS quorumPercent INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS eligibleVoters INT NOT NULL DEFAULT 0
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS associates
\ No newline at end of file
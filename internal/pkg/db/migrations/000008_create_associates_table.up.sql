@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS associates(
+  id VARCHAR(50) PRIMARY KEY,
+  document VARCHAR(14) NOT NULL UNIQUE,
+  name VARCHAR(200),
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  creation TIMESTAMP,
+  updated TIMESTAMP
+)
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS rosterID;
+
+DROP TABLE IF EXISTS roster_members;
+
+DROP TABLE IF EXISTS rosters
\ No newline at end of file
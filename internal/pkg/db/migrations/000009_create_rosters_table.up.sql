@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS rosters(
+  id uuid PRIMARY KEY,
+  name VARCHAR(200),
+  size INT NOT NULL,
+  creation TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS roster_members(
+  rosterID uuid REFERENCES rosters(id),
+  document VARCHAR(14),
+  associateID VARCHAR(50),
+  PRIMARY KEY (rosterID, document)
+);
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS rosterID uuid REFERENCES rosters(id)
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ballot_counts;
+
+DROP TABLE IF EXISTS participations;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS secret
\ No newline at end of file
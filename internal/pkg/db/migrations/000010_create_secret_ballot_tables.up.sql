@@ -0,0 +1,15 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS secret BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS participations(
+  associateID VARCHAR(50),
+  sessionID uuid,
+  document VARCHAR(50),
+  UNIQUE (associateID, sessionID)
+);
+
+CREATE TABLE IF NOT EXISTS ballot_counts(
+  sessionID uuid NOT NULL,
+  vote VARCHAR(50) NOT NULL,
+  votes INT NOT NULL DEFAULT 0,
+  CONSTRAINT ballot_counts_ballot_key UNIQUE (sessionID, vote)
+)
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS vote_changes;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS voteChanges
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS voteChanges BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS vote_changes(
+  associateID VARCHAR(50),
+  sessionID uuid,
+  document VARCHAR(50),
+  action VARCHAR(20),
+  previous VARCHAR(50),
+  vote VARCHAR(50),
+  creation TIMESTAMP
+)
\ No newline at end of file
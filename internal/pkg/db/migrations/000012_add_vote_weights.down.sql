@@ -0,0 +1,9 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS basis;
+
+ALTER TABLE ballot_counts DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE votes DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE roster_members DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE associates DROP COLUMN IF EXISTS weight
\ No newline at end of file
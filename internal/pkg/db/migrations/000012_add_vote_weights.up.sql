@@ -0,0 +1,9 @@
+ALTER TABLE associates ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1;
+
+ALTER TABLE roster_members ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1;
+
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1;
+
+ALTER TABLE ballot_counts ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 0;
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS basis VARCHAR(20) NOT NULL DEFAULT 'headcount'
\ No newline at end of file
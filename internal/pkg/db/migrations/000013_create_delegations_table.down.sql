@@ -0,0 +1,7 @@
+ALTER TABLE ballot_counts DROP COLUMN IF EXISTS delegated;
+
+ALTER TABLE participations DROP COLUMN IF EXISTS proxyDocument;
+
+ALTER TABLE votes DROP COLUMN IF EXISTS proxyDocument;
+
+DROP TABLE IF EXISTS delegations
\ No newline at end of file
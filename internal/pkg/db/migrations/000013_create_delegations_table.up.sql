@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS delegations(
+  id uuid PRIMARY KEY,
+  grantorID VARCHAR(50) NOT NULL,
+  grantorDocument VARCHAR(14) NOT NULL,
+  proxyDocument VARCHAR(14) NOT NULL,
+  scope VARCHAR(20) NOT NULL,
+  agendaID uuid REFERENCES agendas(id),
+  sessionID uuid REFERENCES sessions(id),
+  creation TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS delegations_grantor_scope_idx
+  ON delegations (grantorID, agendaID, COALESCE(sessionID, '00000000-0000-0000-0000-000000000000'));
+
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS proxyDocument VARCHAR(14);
+
+ALTER TABLE participations ADD COLUMN IF NOT EXISTS proxyDocument VARCHAR(14);
+
+ALTER TABLE ballot_counts ADD COLUMN IF NOT EXISTS delegated INT NOT NULL DEFAULT 0
\ No newline at end of file
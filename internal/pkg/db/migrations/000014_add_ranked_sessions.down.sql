@@ -0,0 +1,13 @@
+ALTER TABLE ballot_counts DROP CONSTRAINT IF EXISTS ballot_counts_ballot_key;
+
+ALTER TABLE ballot_counts DROP COLUMN IF EXISTS ranking;
+
+ALTER TABLE ballot_counts ADD CONSTRAINT ballot_counts_ballot_key UNIQUE (sessionID, vote);
+
+ALTER TABLE vote_changes DROP COLUMN IF EXISTS ranking;
+
+ALTER TABLE vote_changes DROP COLUMN IF EXISTS previousRanking;
+
+ALTER TABLE votes DROP COLUMN IF EXISTS ranking;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS method
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS method VARCHAR(20) NOT NULL DEFAULT 'plurality';
+
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS ranking VARCHAR(50)[];
+
+ALTER TABLE vote_changes ADD COLUMN IF NOT EXISTS previousRanking VARCHAR(50)[];
+
+ALTER TABLE vote_changes ADD COLUMN IF NOT EXISTS ranking VARCHAR(50)[];
+
+ALTER TABLE ballot_counts ADD COLUMN IF NOT EXISTS ranking VARCHAR(50)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE ballot_counts
+  DROP CONSTRAINT IF EXISTS ballot_counts_ballot_key,
+  ADD CONSTRAINT ballot_counts_ballot_key UNIQUE (sessionID, vote, ranking)
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE ballot_counts DROP CONSTRAINT IF EXISTS ballot_counts_ballot_key;
+
+ALTER TABLE ballot_counts DROP COLUMN IF EXISTS selections;
+
+ALTER TABLE ballot_counts ADD CONSTRAINT ballot_counts_ballot_key UNIQUE (sessionID, vote, ranking);
+
+ALTER TABLE vote_changes DROP COLUMN IF EXISTS selections;
+
+ALTER TABLE vote_changes DROP COLUMN IF EXISTS previousSelections;
+
+ALTER TABLE votes DROP COLUMN IF EXISTS selections;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS seats;
+
+ALTER TABLE sessions DROP COLUMN IF EXISTS maxSelections
\ No newline at end of file
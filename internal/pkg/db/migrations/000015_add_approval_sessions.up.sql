@@ -0,0 +1,15 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS maxSelections INT NOT NULL DEFAULT 0;
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS seats INT NOT NULL DEFAULT 1;
+
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS selections VARCHAR(50)[];
+
+ALTER TABLE vote_changes ADD COLUMN IF NOT EXISTS previousSelections VARCHAR(50)[];
+
+ALTER TABLE vote_changes ADD COLUMN IF NOT EXISTS selections VARCHAR(50)[];
+
+ALTER TABLE ballot_counts ADD COLUMN IF NOT EXISTS selections VARCHAR(50)[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE ballot_counts
+  DROP CONSTRAINT IF EXISTS ballot_counts_ballot_key,
+  ADD CONSTRAINT ballot_counts_ballot_key UNIQUE (sessionID, vote, ranking, selections)
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS opensAt
\ No newline at end of file
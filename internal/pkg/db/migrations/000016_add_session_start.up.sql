@@ -0,0 +1,5 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS opensAt TIMESTAMP;
+
+UPDATE sessions SET opensAt = creation WHERE opensAt IS NULL;
+
+ALTER TABLE sessions ALTER COLUMN opensAt SET NOT NULL
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS session_transitions
\ No newline at end of file
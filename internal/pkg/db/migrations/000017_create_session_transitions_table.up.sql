@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS session_transitions(
+  id uuid PRIMARY KEY,
+  sessionID uuid,
+  action VARCHAR(20),
+  previousState VARCHAR(20),
+  state VARCHAR(20),
+  reason TEXT,
+  closesAt TIMESTAMP,
+  creation TIMESTAMP
+)
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS state VARCHAR(20) NOT NULL DEFAULT 'scheduled';
+
+UPDATE sessions SET state = 'published' WHERE resultPublished
\ No newline at end of file